@@ -19,6 +19,8 @@
 package flow
 
 import (
+	"sort"
+
 	"github.com/pkg/errors"
 
 	"github.com/portto/blocto-flow-go-sdk/crypto"
@@ -32,6 +34,95 @@ type Account struct {
 	Keys    []*AccountKey
 }
 
+// MinimalSigningKeySet returns the smallest set of key indexes whose combined weight
+// reaches AccountKeyWeightThreshold.
+//
+// Revoked keys are never selected. The indexes are ordered by descending key weight,
+// with ties broken by ascending key index.
+//
+// This function returns an error if the usable keys of this account do not reach the
+// threshold.
+func (a Account) MinimalSigningKeySet() ([]int, error) {
+	return a.minimalSigningKeySet()
+}
+
+// minimalSigningKeySet returns the smallest set of key indexes that includes all of the
+// required key indexes and whose combined weight reaches AccountKeyWeightThreshold.
+//
+// The required indexes are listed first, followed by the additional keys selected by
+// descending key weight.
+func (a Account) minimalSigningKeySet(required ...int) ([]int, error) {
+	indexes := make([]int, 0)
+	selected := make(map[int]struct{})
+	weight := 0
+
+	for _, index := range required {
+		if _, ok := selected[index]; ok {
+			continue
+		}
+
+		key := a.key(index)
+		if key == nil {
+			return nil, errors.Errorf("account %s has no key with index %d", a.Address, index)
+		}
+
+		if key.Revoked {
+			return nil, errors.Errorf("key %d of account %s is revoked", index, a.Address)
+		}
+
+		indexes = append(indexes, index)
+		selected[index] = struct{}{}
+		weight += key.Weight
+	}
+
+	if weight >= AccountKeyWeightThreshold {
+		return indexes, nil
+	}
+
+	keys := make([]*AccountKey, 0, len(a.Keys))
+	for _, key := range a.Keys {
+		if _, ok := selected[key.Index]; ok || key.Revoked || key.Weight <= 0 {
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		if keys[i].Weight != keys[j].Weight {
+			return keys[i].Weight > keys[j].Weight
+		}
+
+		return keys[i].Index < keys[j].Index
+	})
+
+	for _, key := range keys {
+		indexes = append(indexes, key.Index)
+		weight += key.Weight
+
+		if weight >= AccountKeyWeightThreshold {
+			return indexes, nil
+		}
+	}
+
+	return nil, errors.Errorf(
+		"usable keys have a total weight of %d, which is below the threshold of %d",
+		weight,
+		AccountKeyWeightThreshold,
+	)
+}
+
+// key returns the key with the given index, or nil if this account has no such key.
+func (a Account) key(index int) *AccountKey {
+	for _, key := range a.Keys {
+		if key.Index == index {
+			return key
+		}
+	}
+
+	return nil
+}
+
 // AccountKeyWeightThreshold is the total key weight required to authorize access to an account.
 const AccountKeyWeightThreshold int = 1000
 
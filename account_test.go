@@ -0,0 +1,68 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portto/blocto-flow-go-sdk"
+)
+
+func TestAccount_MinimalSigningKeySet(t *testing.T) {
+	newAccount := func(weights ...int) flow.Account {
+		keys := make([]*flow.AccountKey, len(weights))
+		for i, weight := range weights {
+			keys[i] = &flow.AccountKey{
+				Index:  i,
+				Weight: weight,
+			}
+		}
+
+		return flow.Account{Keys: keys}
+	}
+
+	t.Run("Three keys", func(t *testing.T) {
+		account := newAccount(600, 500, 400)
+
+		indexes, err := account.MinimalSigningKeySet()
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1}, indexes)
+	})
+
+	t.Run("Revoked key", func(t *testing.T) {
+		account := newAccount(600, 500, 400)
+		account.Keys[1].Revoked = true
+
+		indexes, err := account.MinimalSigningKeySet()
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 2}, indexes)
+	})
+
+	t.Run("Insufficient weight", func(t *testing.T) {
+		account := newAccount(600, 500, 400)
+		account.Keys[0].Revoked = true
+
+		indexes, err := account.MinimalSigningKeySet()
+		assert.Error(t, err)
+		assert.Nil(t, indexes)
+	})
+}
@@ -23,6 +23,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+
+	"github.com/onflow/cadence"
 )
 
 // Address represents the 8 byte address of an account.
@@ -87,6 +89,21 @@ func (gen *AddressGenerator) SetIndex(i uint) *AddressGenerator {
 	return gen
 }
 
+// AddressAtIndex generates the account address at the given addressing state, without
+// changing the state of the generator.
+//
+// The address at index 1 is the service account address of the chain, and each following
+// index is the address of the next account created on the chain.
+func (gen *AddressGenerator) AddressAtIndex(i uint64) Address {
+	if i > maxState {
+		panic(
+			fmt.Sprintf("addressing state must be less than or equal to %d", maxState),
+		)
+	}
+
+	return generateAddress(gen.chainID, addressState(i))
+}
+
 // addressState represents the internal state of the address generation mechanism
 type addressState uint64
 
@@ -141,6 +158,38 @@ func HexToAddress(h string) Address {
 	return BytesToAddress(b)
 }
 
+// ParseAddress parses a hex string, with or without a 0x prefix, into an Address.
+//
+// Unlike HexToAddress, this function returns an error if the string is empty, contains
+// non-hex characters or encodes more than AddressLength bytes. Shorter addresses are
+// left-padded with zeros.
+func ParseAddress(s string) (Address, error) {
+	h := s
+	if has0xPrefix(h) {
+		h = h[2:]
+	}
+
+	if len(h) == 0 {
+		return EmptyAddress, fmt.Errorf("address %q is empty", s)
+	}
+
+	if len(h)%2 == 1 {
+		h = "0" + h
+	}
+
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return EmptyAddress, fmt.Errorf("address %q is not valid hex: %w", s, err)
+	}
+
+	address, err := NormalizeAddress(b)
+	if err != nil {
+		return EmptyAddress, fmt.Errorf("address %q is too long: %w", s, err)
+	}
+
+	return address, nil
+}
+
 // has0xPrefix validates str begins with '0x' or '0X'.
 func has0xPrefix(str string) bool {
 	return len(str) >= 2 && str[0] == '0' && (str[1] == 'x' || str[1] == 'X')
@@ -159,6 +208,19 @@ func BytesToAddress(b []byte) Address {
 	return a
 }
 
+// NormalizeAddress converts a variable-length byte slice to an address.
+//
+// Inputs shorter than AddressLength are left-padded with zeros. Unlike BytesToAddress,
+// this function returns an error if the input is longer than AddressLength instead of
+// truncating it.
+func NormalizeAddress(b []byte) (Address, error) {
+	if len(b) > AddressLength {
+		return EmptyAddress, fmt.Errorf("address must be at most %d bytes, got %d", AddressLength, len(b))
+	}
+
+	return BytesToAddress(b), nil
+}
+
 // Bytes returns the byte representation of the address.
 func (a Address) Bytes() []byte { return a[:] }
 
@@ -167,11 +229,35 @@ func (a Address) Hex() string {
 	return hex.EncodeToString(a.Bytes())
 }
 
+// HexWithPrefix returns the 0x-prefixed hex string representation of the address.
+//
+// The hex string is lowercase and always includes all leading zeros.
+func (a Address) HexWithPrefix() string {
+	return "0x" + a.Hex()
+}
+
+// Short returns an abbreviated representation of the address for logs and user interfaces,
+// made of the first and last four hex digits (e.g. 0x1234…abcd).
+func (a Address) Short() string {
+	h := a.Hex()
+	return "0x" + h[:4] + "…" + h[len(h)-4:]
+}
+
 // String returns the string representation of the address.
 func (a Address) String() string {
 	return a.Hex()
 }
 
+// ToCadence converts the address to a Cadence address value.
+func (a Address) ToCadence() cadence.Address {
+	return cadence.Address(a)
+}
+
+// CadenceAddressToFlow converts a Cadence address value to an Address.
+func CadenceAddressToFlow(address cadence.Address) Address {
+	return Address(address)
+}
+
 func (a Address) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf("\"%s\"", a.Hex())), nil
 }
@@ -247,7 +333,7 @@ func generateAddress(chain ChainID, state addressState) Address {
 // This is an off-chain check that only tells whether the address format is
 // valid. If the function returns true, this does not mean a Flow account with
 // this address has been generated. Such a test would require an on-chain check.
-func (a *Address) IsValid(chain ChainID) bool {
+func (a Address) IsValid(chain ChainID) bool {
 	codeWord := a.uint64()
 	codeWord ^= chainCustomizer(chain)
 
@@ -25,6 +25,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/onflow/cadence"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -46,6 +47,15 @@ func TestAddressJSON(t *testing.T) {
 	assert.Equal(t, addr, out.Address)
 }
 
+func TestAddressCadenceConversion(t *testing.T) {
+	addr := ServiceAddress(Testnet)
+
+	cadenceAddr := addr.ToCadence()
+	assert.Equal(t, cadence.BytesToAddress(addr.Bytes()), cadenceAddr)
+
+	assert.Equal(t, addr, CadenceAddressToFlow(cadenceAddr))
+}
+
 func TestAddressConstants(t *testing.T) {
 	// check n and k fit in 8 and 6 bytes
 	assert.LessOrEqual(t, linearCodeN, 8*8)
@@ -282,3 +292,118 @@ func TestAddressesIntersection(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalizeAddress(t *testing.T) {
+	t.Run("Short input is left-padded", func(t *testing.T) {
+		address, err := NormalizeAddress([]byte{0x01, 0x02, 0x03, 0x04})
+		require.NoError(t, err)
+		assert.Equal(t, HexToAddress("0000000001020304"), address)
+	})
+
+	t.Run("Full-length input is unchanged", func(t *testing.T) {
+		b := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+		address, err := NormalizeAddress(b)
+		require.NoError(t, err)
+		assert.Equal(t, b, address.Bytes())
+	})
+
+	t.Run("Overlong input", func(t *testing.T) {
+		_, err := NormalizeAddress(make([]byte, AddressLength+1))
+		assert.Error(t, err)
+	})
+}
+
+func TestAddress_IsValid(t *testing.T) {
+	serviceAddresses := map[ChainID]Address{
+		Mainnet:  HexToAddress("e467b9dd11fa00df"),
+		Testnet:  HexToAddress("8c5303eaa26202d6"),
+		Emulator: HexToAddress("f8d6e0586b0a20c7"),
+	}
+
+	for chain, address := range serviceAddresses {
+		t.Run(string(chain), func(t *testing.T) {
+			assert.True(t, address.IsValid(chain))
+
+			for otherChain := range serviceAddresses {
+				if otherChain != chain {
+					assert.False(t, address.IsValid(otherChain))
+				}
+			}
+
+			corrupted := address
+			corrupted[AddressLength-1] ^= 0x01
+			assert.False(t, corrupted.IsValid(chain))
+		})
+	}
+
+	t.Run("Empty address", func(t *testing.T) {
+		assert.False(t, EmptyAddress.IsValid(Mainnet))
+	})
+}
+
+func TestAddressGenerator_AddressAtIndex(t *testing.T) {
+	// accounts created by the emulator on startup, followed by the first user accounts
+	expected := []Address{
+		HexToAddress("f8d6e0586b0a20c7"),
+		HexToAddress("ee82856bf20e2aa6"),
+		HexToAddress("0ae53cb6e3f42a79"),
+		HexToAddress("e5a8b7f23e8b548f"),
+		HexToAddress("01cf0e2f2f715450"),
+		HexToAddress("179b6b1cb6755e31"),
+	}
+
+	gen := NewAddressGenerator(Emulator)
+
+	for i, address := range expected {
+		assert.Equal(t, address, gen.AddressAtIndex(uint64(i+1)))
+	}
+
+	// AddressAtIndex does not change the generator state
+	for _, address := range expected {
+		assert.Equal(t, address, gen.NextAddress())
+	}
+}
+
+func TestAddress_HexWithPrefix(t *testing.T) {
+	address := HexToAddress("0000012345abcdef")
+
+	assert.Equal(t, "0x0000012345abcdef", address.HexWithPrefix())
+	assert.Equal(t, "0x0000000000000000", EmptyAddress.HexWithPrefix())
+}
+
+func TestAddress_Short(t *testing.T) {
+	address := HexToAddress("0000012345abcdef")
+
+	assert.Equal(t, "0x0000…cdef", address.Short())
+}
+
+func TestParseAddress(t *testing.T) {
+	expected := HexToAddress("0000012345abcdef")
+
+	t.Run("Prefixed", func(t *testing.T) {
+		address, err := ParseAddress("0x0000012345abcdef")
+		require.NoError(t, err)
+		assert.Equal(t, expected, address)
+	})
+
+	t.Run("Bare", func(t *testing.T) {
+		address, err := ParseAddress("12345abcdef")
+		require.NoError(t, err)
+		assert.Equal(t, expected, address)
+	})
+
+	t.Run("Too long", func(t *testing.T) {
+		_, err := ParseAddress("0x000000012345abcdef")
+		assert.Error(t, err)
+	})
+
+	t.Run("Non-hex", func(t *testing.T) {
+		_, err := ParseAddress("0x12345abcdefg")
+		assert.Error(t, err)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := ParseAddress("0x")
+		assert.Error(t, err)
+	})
+}
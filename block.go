@@ -42,5 +42,15 @@ type BlockPayload struct {
 	Seals                []*BlockSeal
 }
 
+// CollectionGuarantees returns the collection guarantees included in the block payload.
+func (b Block) CollectionGuarantees() []CollectionGuarantee {
+	guarantees := make([]CollectionGuarantee, len(b.BlockPayload.CollectionGuarantees))
+	for i, guarantee := range b.BlockPayload.CollectionGuarantees {
+		guarantees[i] = *guarantee
+	}
+
+	return guarantees
+}
+
 // TODO: define block seal struct
 type BlockSeal struct{}
@@ -0,0 +1,117 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+
+	"github.com/portto/blocto-flow-go-sdk"
+)
+
+// GetTransactionsByBlockID gets all transactions included in a block, in execution order.
+//
+// The Access API implemented by this client has no RPC to list the transactions of a
+// block, so the transactions are fetched through the collections guaranteed in the block.
+//
+// An empty slice is returned for a block that contains no transactions. RPC errors keep
+// their gRPC status code.
+func (c *Client) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	block, err := c.GetBlockByID(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	txIDs, err := c.getBlockTransactionIDs(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*flow.Transaction, 0, len(txIDs))
+
+	for _, txID := range txIDs {
+		tx, err := c.GetTransaction(ctx, txID)
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+
+// GetTransactionResultsByBlockID gets the results of all transactions included in a block,
+// in execution order.
+//
+// The Access API implemented by this client has no RPC to list the transaction results of
+// a block, so the results are fetched through the collections guaranteed in the block.
+// The error message of a failed transaction is returned as the Error of its result.
+//
+// An empty slice is returned for a block that contains no transactions. RPC errors keep
+// their gRPC status code.
+func (c *Client) GetTransactionResultsByBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+) ([]*flow.TransactionResult, error) {
+	block, err := c.GetBlockByID(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getBlockTransactionResults(ctx, block)
+}
+
+// getBlockTransactionResults returns the results of the transactions in a block, in
+// execution order.
+func (c *Client) getBlockTransactionResults(ctx context.Context, block *flow.Block) ([]*flow.TransactionResult, error) {
+	txIDs, err := c.getBlockTransactionIDs(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*flow.TransactionResult, 0, len(txIDs))
+
+	for _, txID := range txIDs {
+		result, err := c.GetTransactionResult(ctx, txID)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// getBlockTransactionIDs returns the IDs of the transactions in the collections
+// guaranteed in a block, in execution order.
+func (c *Client) getBlockTransactionIDs(ctx context.Context, block *flow.Block) ([]flow.Identifier, error) {
+	txIDs := make([]flow.Identifier, 0)
+
+	for _, guarantee := range block.CollectionGuarantees() {
+		collection, err := c.GetCollection(ctx, guarantee.CollectionID)
+		if err != nil {
+			return nil, err
+		}
+
+		txIDs = append(txIDs, collection.TransactionIDs...)
+	}
+
+	return txIDs, nil
+}
@@ -0,0 +1,166 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/portto/blocto-flow-go-sdk"
+	"github.com/portto/blocto-flow-go-sdk/client"
+	"github.com/portto/blocto-flow-go-sdk/client/convert"
+	"github.com/portto/blocto-flow-go-sdk/test"
+)
+
+func mockBlockByID(t *testing.T, ctx context.Context, rpc *MockRPCClient, block *flow.Block) {
+	msg, err := convert.BlockToMessage(*block)
+	require.NoError(t, err)
+
+	rpc.On("GetBlockByID", ctx, &access.GetBlockByIDRequest{Id: block.ID.Bytes()}).
+		Return(&access.BlockResponse{Block: msg}, nil)
+}
+
+func TestClient_GetTransactionsByBlockID(t *testing.T) {
+	blocks := test.BlockGenerator()
+	collections := test.CollectionGenerator()
+	transactions := test.TransactionGenerator()
+
+	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		block := blocks.New()
+		mockBlockByID(t, ctx, rpc, block)
+
+		collection := collections.New()
+
+		rpc.On("GetCollectionByID", ctx, mock.Anything).
+			Return(&access.CollectionResponse{Collection: convert.CollectionToMessage(*collection)}, nil)
+
+		expectedTx := transactions.New()
+
+		txMsg, err := convert.TransactionToMessage(*expectedTx)
+		require.NoError(t, err)
+
+		rpc.On("GetTransaction", ctx, mock.Anything).
+			Return(&access.TransactionResponse{Transaction: txMsg}, nil)
+
+		txs, err := c.GetTransactionsByBlockID(ctx, block.ID)
+		require.NoError(t, err)
+
+		require.Len(t, txs, len(block.CollectionGuarantees())*len(collection.TransactionIDs))
+		for _, tx := range txs {
+			assert.Equal(t, expectedTx.ID(), tx.ID())
+		}
+
+		for _, txID := range collection.TransactionIDs {
+			rpc.AssertCalled(t, "GetTransaction", ctx, &access.GetTransactionRequest{Id: txID.Bytes()})
+		}
+	}))
+
+	t.Run("Empty block", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		block := blocks.New()
+		block.BlockPayload.CollectionGuarantees = nil
+		mockBlockByID(t, ctx, rpc, block)
+
+		txs, err := c.GetTransactionsByBlockID(ctx, block.ID)
+		require.NoError(t, err)
+
+		assert.NotNil(t, txs)
+		assert.Empty(t, txs)
+	}))
+
+	t.Run("Not found error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		block := blocks.New()
+
+		rpc.On("GetBlockByID", ctx, mock.Anything).
+			Return(nil, errNotFound)
+
+		txs, err := c.GetTransactionsByBlockID(ctx, block.ID)
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Nil(t, txs)
+	}))
+}
+
+func TestClient_GetTransactionResultsByBlockID(t *testing.T) {
+	blocks := test.BlockGenerator()
+	events := test.EventGenerator()
+
+	t.Run("Sealed and failed transactions", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		block := blocks.New()
+		block.BlockPayload.CollectionGuarantees = block.BlockPayload.CollectionGuarantees[:1]
+		mockBlockByID(t, ctx, rpc, block)
+
+		sealedID := flow.HexToID("01")
+		failedID := flow.HexToID("02")
+
+		collection := flow.Collection{TransactionIDs: []flow.Identifier{sealedID, failedID}}
+
+		rpc.On("GetCollectionByID", ctx, mock.Anything).
+			Return(&access.CollectionResponse{Collection: convert.CollectionToMessage(collection)}, nil)
+
+		event := events.New()
+
+		eventMsg, err := convert.EventToMessage(event)
+		require.NoError(t, err)
+
+		rpc.On("GetTransactionResult", ctx, &access.GetTransactionRequest{Id: sealedID.Bytes()}).
+			Return(&access.TransactionResultResponse{
+				Status: entities.TransactionStatus_SEALED,
+				Events: []*entities.Event{eventMsg},
+			}, nil)
+
+		rpc.On("GetTransactionResult", ctx, &access.GetTransactionRequest{Id: failedID.Bytes()}).
+			Return(&access.TransactionResultResponse{
+				Status:       entities.TransactionStatus_SEALED,
+				StatusCode:   1,
+				ErrorMessage: "panic: insufficient balance",
+			}, nil)
+
+		results, err := c.GetTransactionResultsByBlockID(ctx, block.ID)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		assert.Equal(t, flow.TransactionStatusSealed, results[0].Status)
+		assert.NoError(t, results[0].Error)
+		assert.Equal(t, []flow.Event{event}, results[0].Events)
+
+		assert.Equal(t, flow.TransactionStatusSealed, results[1].Status)
+		require.Error(t, results[1].Error)
+		assert.Equal(t, "panic: insufficient balance", results[1].Error.Error())
+	}))
+
+	t.Run("Empty block", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		block := blocks.New()
+		block.BlockPayload.CollectionGuarantees = nil
+		mockBlockByID(t, ctx, rpc, block)
+
+		results, err := c.GetTransactionResultsByBlockID(ctx, block.ID)
+		require.NoError(t, err)
+
+		assert.NotNil(t, results)
+		assert.Empty(t, results)
+	}))
+}
@@ -29,15 +29,20 @@ package client
 
 import (
 	"context"
+	"errors"
 	"github.com/golang/protobuf/ptypes"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow/protobuf/go/flow/access"
 	"google.golang.org/grpc"
+	_ "google.golang.org/grpc/encoding/gzip" // register the gzip compressor for WithCompression
 
 	"github.com/portto/blocto-flow-go-sdk"
 	"github.com/portto/blocto-flow-go-sdk/client/convert"
+	"github.com/portto/blocto-flow-go-sdk/crypto"
 )
 
 // An RPCClient is an RPC client for the Flow Access API.
@@ -49,6 +54,13 @@ type RPCClient interface {
 type Client struct {
 	rpcClient RPCClient
 	close     func() error
+
+	// addr is the address of the access node, or empty if the client was created
+	// from an RPC client.
+	addr string
+
+	sporksMu sync.RWMutex
+	sporks   map[SporkID]*Client
 }
 
 // New initializes a Flow client with the default gRPC provider.
@@ -65,9 +77,22 @@ func New(addr string, opts ...grpc.DialOption) (*Client, error) {
 	return &Client{
 		rpcClient: grpcClient,
 		close:     func() error { return conn.Close() },
+		addr:      addr,
 	}, nil
 }
 
+// WithCompression returns a dial option that compresses all requests with the named compressor.
+//
+// The gzip compressor is registered by this package and can be enabled with WithCompression("gzip").
+// Other compressors must be registered with the gRPC encoding package before use.
+//
+// Compression reduces bandwidth usage for large responses such as event queries, at the cost
+// of additional CPU time to compress and decompress each message on both ends. It is rarely
+// beneficial for small requests or on fast local networks.
+func WithCompression(name string) grpc.DialOption {
+	return grpc.WithDefaultCallOptions(grpc.UseCompressor(name))
+}
+
 // NewFromRPCClient initializes a Flow client using a pre-configured gRPC provider.
 func NewFromRPCClient(rpcClient RPCClient) *Client {
 	return &Client{
@@ -87,7 +112,54 @@ func (c *Client) Ping(ctx context.Context) error {
 	return err
 }
 
+// MaxAllowedGasLimit returns the maximum gas limit a transaction can request.
+//
+// The limit is a protocol constant, flow.MaxGasLimit, that applies to every Flow
+// network; the Access API does not report it, so the access node is not contacted and
+// the returned error is always nil.
+func (c *Client) MaxAllowedGasLimit(ctx context.Context) (uint64, error) {
+	return flow.MaxGasLimit, nil
+}
+
+// ResolveImports replaces the import placeholders in a script with the addresses of
+// well-known contracts on the network of the access node.
+//
+// For example, an import from 0xFUNGIBLETOKEN is resolved to the address of the
+// FungibleToken contract on the network. See flow.ResolveImports for details.
+func (c *Client) ResolveImports(ctx context.Context, script []byte) ([]byte, error) {
+	chainID, err := c.getChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, ok := flow.KnownContractAddresses(chainID)
+	if !ok {
+		return nil, errors.New(errorMessage("unknown chain ID %q", chainID))
+	}
+
+	return flow.ResolveImports(script, contracts)
+}
+
+func (c *Client) getChainID(ctx context.Context) (flow.ChainID, error) {
+	res, err := c.rpcClient.GetNetworkParameters(ctx, &access.GetNetworkParametersRequest{})
+	if err != nil {
+		return "", newRPCError(err)
+	}
+
+	return flow.ChainID(res.GetChainId()), nil
+}
+
 // GetLatestBlockHeader gets the latest sealed or unsealed block header.
+//
+// The header is cheaper to fetch than the full block returned by GetLatestBlock, and
+// is sufficient to set the reference block of a transaction:
+//
+//	header, err := c.GetLatestBlockHeader(ctx, true)
+//	if err != nil {
+//		return err
+//	}
+//
+//	tx.SetReferenceBlockID(header.ID)
 func (c *Client) GetLatestBlockHeader(
 	ctx context.Context,
 	isSealed bool,
@@ -104,6 +176,26 @@ func (c *Client) GetLatestBlockHeader(
 	return getBlockHeaderResult(res)
 }
 
+// GetLatestBlockHeight gets the height of the latest sealed or unsealed block.
+//
+// Only the block height is read from the response; the rest of the header is not converted.
+func (c *Client) GetLatestBlockHeight(ctx context.Context, isSealed bool) (uint64, error) {
+	req := &access.GetLatestBlockHeaderRequest{
+		IsSealed: isSealed,
+	}
+
+	res, err := c.rpcClient.GetLatestBlockHeader(ctx, req)
+	if err != nil {
+		return 0, newRPCError(err)
+	}
+
+	if res.GetBlock() == nil {
+		return 0, newMessageToEntityError(entityBlockHeader, convert.ErrEmptyMessage)
+	}
+
+	return res.GetBlock().GetHeight(), nil
+}
+
 // GetBlockHeaderByID gets a block header by ID.
 func (c *Client) GetBlockHeaderByID(ctx context.Context, blockID flow.Identifier) (*flow.BlockHeader, error) {
 	req := &access.GetBlockHeaderByIDRequest{
@@ -211,8 +303,38 @@ func (c *Client) GetCollection(ctx context.Context, colID flow.Identifier) (*flo
 	return &result, nil
 }
 
+// A SendOption configures how a transaction is submitted.
+type SendOption func(*sendConfig)
+
+type sendConfig struct {
+	skipValidation bool
+}
+
+// WithoutValidation disables the local validation performed by SendTransaction,
+// so that the transaction is always forwarded to the access node.
+func WithoutValidation() SendOption {
+	return func(c *sendConfig) {
+		c.skipValidation = true
+	}
+}
+
 // SendTransaction submits a transaction to the network.
-func (c *Client) SendTransaction(ctx context.Context, tx flow.Transaction) error {
+//
+// The transaction is validated with Validate before it is submitted. If it is invalid,
+// an InvalidTransactionError is returned without contacting the access node. Validation
+// can be disabled with the WithoutValidation option.
+func (c *Client) SendTransaction(ctx context.Context, tx flow.Transaction, opts ...SendOption) error {
+	var conf sendConfig
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	if !conf.skipValidation {
+		if err := tx.Validate(); err != nil {
+			return InvalidTransactionError{Err: err}
+		}
+	}
+
 	txMsg, err := convert.TransactionToMessage(tx)
 	if err != nil {
 		return newEntityToMessageError(entityTransaction, err)
@@ -268,6 +390,22 @@ func (c *Client) GetTransactionResult(ctx context.Context, txID flow.Identifier)
 	return &result, nil
 }
 
+// GetTransactionEvents gets the decoded events emitted by a transaction.
+//
+// An empty slice is returned for a transaction that emitted no events.
+func (c *Client) GetTransactionEvents(ctx context.Context, txID flow.Identifier) ([]flow.Event, error) {
+	result, err := c.GetTransactionResult(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Events == nil {
+		return []flow.Event{}, nil
+	}
+
+	return result.Events, nil
+}
+
 // GetAccount is an alias for GetAccountAtLatestBlock.
 func (c *Client) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
 	return c.GetAccountAtLatestBlock(ctx, address)
@@ -284,6 +422,47 @@ func (c *Client) GetAccountAtLatestBlock(ctx context.Context, address flow.Addre
 		return nil, newRPCError(err)
 	}
 
+	return getAccountResult(res)
+}
+
+// GetAccountAtBlockHeight gets an account by address at the given block height.
+func (c *Client) GetAccountAtBlockHeight(
+	ctx context.Context,
+	address flow.Address,
+	blockHeight uint64,
+) (*flow.Account, error) {
+	req := &access.GetAccountAtBlockHeightRequest{
+		Address:     address.Bytes(),
+		BlockHeight: blockHeight,
+	}
+
+	res, err := c.rpcClient.GetAccountAtBlockHeight(ctx, req)
+	if err != nil {
+		return nil, newRPCError(err)
+	}
+
+	return getAccountResult(res)
+}
+
+// GetAccountAtBlockID gets an account by address at the given block.
+//
+// This allows an account read and the reference block of a transaction to be pinned to
+// the same block. The Access API only supports account reads by block height, so the
+// block header is fetched first to resolve its height.
+func (c *Client) GetAccountAtBlockID(
+	ctx context.Context,
+	address flow.Address,
+	blockID flow.Identifier,
+) (*flow.Account, error) {
+	header, err := c.GetBlockHeaderByID(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetAccountAtBlockHeight(ctx, address, header.Height)
+}
+
+func getAccountResult(res *access.AccountResponse) (*flow.Account, error) {
 	account, err := convert.MessageToAccount(res.GetAccount())
 	if err != nil {
 		return nil, newMessageToEntityError(entityAccount, err)
@@ -292,6 +471,119 @@ func (c *Client) GetAccountAtLatestBlock(ctx context.Context, address flow.Addre
 	return &account, nil
 }
 
+// ValidateSignatureKey checks that the given key index can be used to produce
+// signatures for an account.
+//
+// This function fetches the account at the latest sealed block and returns an error if
+// the key does not exist, has been revoked, or does not use the given signature algorithm.
+func (c *Client) ValidateSignatureKey(
+	ctx context.Context,
+	address flow.Address,
+	keyIndex int,
+	sigAlgo crypto.SignatureAlgorithm,
+) error {
+	account, err := c.GetAccountAtLatestBlock(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range account.Keys {
+		if key.Index != keyIndex {
+			continue
+		}
+
+		if key.Revoked {
+			return errors.New(errorMessage("key %d on account %s is revoked", keyIndex, address))
+		}
+
+		if key.SigAlgo != sigAlgo {
+			return errors.New(errorMessage(
+				"key %d on account %s uses signature algorithm %s, not %s",
+				keyIndex,
+				address,
+				key.SigAlgo,
+				sigAlgo,
+			))
+		}
+
+		return nil
+	}
+
+	return errors.New(errorMessage("account %s has no key at index %d", address, keyIndex))
+}
+
+// An AffordOption configures the checks performed by CanAffordTransaction.
+type AffordOption func(*affordConfig)
+
+type affordConfig struct {
+	transferArguments []int
+}
+
+// WithTransferArgument includes the UFix64 transaction argument at the given index in the
+// amount the payer must be able to afford.
+//
+// This is useful for token transfers where the payer is also the sender of the tokens.
+func WithTransferArgument(index int) AffordOption {
+	return func(c *affordConfig) {
+		c.transferArguments = append(c.transferArguments, index)
+	}
+}
+
+// CanAffordTransaction checks if the payer of a transaction has a balance large enough
+// to cover the estimated transaction fee.
+//
+// Transferred amounts can be included in the check with the WithTransferArgument option.
+//
+// If the balance is insufficient, this function returns false along with an
+// InsufficientBalanceError that describes the shortfall.
+func (c *Client) CanAffordTransaction(
+	ctx context.Context,
+	tx *flow.Transaction,
+	estimatedFee cadence.UFix64,
+	opts ...AffordOption,
+) (bool, error) {
+	var conf affordConfig
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	required := uint64(estimatedFee)
+
+	for _, i := range conf.transferArguments {
+		arg, err := tx.Argument(i)
+		if err != nil {
+			return false, err
+		}
+
+		amount, ok := arg.(cadence.UFix64)
+		if !ok {
+			return false, errors.New(errorMessage("argument %d is not a UFix64 value", i))
+		}
+
+		if required > math.MaxUint64-uint64(amount) {
+			return false, errors.New(errorMessage("total of fee and transferred amounts overflows at argument %d", i))
+		}
+
+		required += uint64(amount)
+	}
+
+	payer, err := c.GetAccountAtLatestBlock(ctx, tx.Payer)
+	if err != nil {
+		return false, err
+	}
+
+	if payer.Balance < required {
+		return false, InsufficientBalanceError{
+			Address:   payer.Address,
+			Balance:   payer.Balance,
+			Required:  required,
+			Shortfall: required - payer.Balance,
+		}
+	}
+
+	return true, nil
+}
+
 // ExecuteScriptAtLatestBlock executes a read-only Cadence script against the latest sealed execution state.
 func (c *Client) ExecuteScriptAtLatestBlock(
 	ctx context.Context,
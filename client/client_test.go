@@ -20,7 +20,10 @@ package client_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/golang/protobuf/ptypes"
+	"math"
 	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/flow/protobuf/go/flow/access"
@@ -31,10 +34,12 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"testing"
+	"time"
 
 	"github.com/portto/blocto-flow-go-sdk"
 	"github.com/portto/blocto-flow-go-sdk/client"
 	"github.com/portto/blocto-flow-go-sdk/client/convert"
+	"github.com/portto/blocto-flow-go-sdk/crypto"
 	"github.com/portto/blocto-flow-go-sdk/test"
 )
 
@@ -96,6 +101,30 @@ func TestClient_GetLatestBlockHeader(t *testing.T) {
 		assert.Equal(t, expectedHeader, *header)
 	}))
 
+	for _, isSealed := range []bool{true, false} {
+		isSealed := isSealed
+
+		t.Run(fmt.Sprintf("Sealed %t", isSealed), clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+			expectedHeader := flow.BlockHeader{
+				ID:        flow.HexToID("f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b"),
+				ParentID:  flow.HexToID("0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13bf"),
+				Height:    42,
+				Timestamp: time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC),
+			}
+
+			rpc.On("GetLatestBlockHeader", ctx, &access.GetLatestBlockHeaderRequest{IsSealed: isSealed}).
+				Return(blockHeaderResponse(t, expectedHeader), nil)
+
+			header, err := c.GetLatestBlockHeader(ctx, isSealed)
+			require.NoError(t, err)
+
+			assert.Equal(t, expectedHeader.ID, header.ID)
+			assert.Equal(t, expectedHeader.ParentID, header.ParentID)
+			assert.Equal(t, expectedHeader.Height, header.Height)
+			assert.True(t, expectedHeader.Timestamp.Equal(header.Timestamp))
+		}))
+	}
+
 	t.Run("Internal error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
 		rpc.On("GetLatestBlockHeader", ctx, mock.Anything).
 			Return(nil, errInternal)
@@ -107,6 +136,91 @@ func TestClient_GetLatestBlockHeader(t *testing.T) {
 	}))
 }
 
+func TestClient_MaxAllowedGasLimit(t *testing.T) {
+	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		limit, err := c.MaxAllowedGasLimit(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, flow.MaxGasLimit, limit)
+
+		tx := flow.NewTransaction().SetGasLimit(limit + 1)
+		assert.True(t, tx.ExceedsMaxGas(limit))
+
+		rpc.AssertNumberOfCalls(t, "GetNetworkParameters", 0)
+	}))
+}
+
+func TestClient_ResolveImports(t *testing.T) {
+	script := []byte(`
+		import FungibleToken from 0xFUNGIBLETOKEN
+		import FlowToken from 0xFLOWTOKEN // native token
+		import Foo from 0x01
+	`)
+
+	t.Run("Emulator", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetNetworkParameters", ctx, mock.Anything).
+			Return(&access.GetNetworkParametersResponse{ChainId: string(flow.Emulator)}, nil)
+
+		resolved, err := c.ResolveImports(ctx, script)
+		require.NoError(t, err)
+
+		assert.Equal(t, `
+		import FungibleToken from 0xee82856bf20e2aa6
+		import FlowToken from 0x0ae53cb6e3f42a79 // native token
+		import Foo from 0x01
+	`, string(resolved))
+	}))
+
+	t.Run("Unknown chain", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetNetworkParameters", ctx, mock.Anything).
+			Return(&access.GetNetworkParametersResponse{ChainId: "flow-unknown"}, nil)
+
+		_, err := c.ResolveImports(ctx, script)
+		assert.Error(t, err)
+	}))
+}
+
+func TestClient_GetLatestBlockHeight(t *testing.T) {
+	blocks := test.BlockGenerator()
+
+	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		headerA := blocks.New().BlockHeader
+		headerB := blocks.New().BlockHeader
+
+		msgA, err := convert.BlockHeaderToMessage(headerA)
+		require.NoError(t, err)
+
+		msgB, err := convert.BlockHeaderToMessage(headerB)
+		require.NoError(t, err)
+
+		rpc.On("GetLatestBlockHeader", ctx, mock.Anything).
+			Return(&access.BlockHeaderResponse{Block: msgA}, nil).
+			Once()
+
+		rpc.On("GetLatestBlockHeader", ctx, mock.Anything).
+			Return(&access.BlockHeaderResponse{Block: msgB}, nil).
+			Once()
+
+		heightA, err := c.GetLatestBlockHeight(ctx, true)
+		require.NoError(t, err)
+
+		heightB, err := c.GetLatestBlockHeight(ctx, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, headerA.Height, heightA)
+		assert.Equal(t, headerB.Height, heightB)
+		assert.GreaterOrEqual(t, heightB, heightA)
+	}))
+
+	t.Run("Internal error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetLatestBlockHeader", ctx, mock.Anything).
+			Return(nil, errInternal)
+
+		_, err := c.GetLatestBlockHeight(ctx, true)
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	}))
+}
+
 func TestClient_GetBlockHeaderByID(t *testing.T) {
 	blocks := test.BlockGenerator()
 	ids := test.IdentifierGenerator()
@@ -333,6 +447,29 @@ func TestClient_SendTransaction(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, codes.Internal, status.Code(err))
 	}))
+
+	t.Run("Invalid transaction", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		tx := transactions.New().SetPayer(flow.EmptyAddress)
+
+		err := c.SendTransaction(ctx, *tx)
+		assert.Error(t, err)
+
+		var invalidErr client.InvalidTransactionError
+		assert.True(t, errors.As(err, &invalidErr))
+
+		rpc.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+	}))
+
+	t.Run("Without validation", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		tx := transactions.New().SetPayer(flow.EmptyAddress)
+
+		rpc.On("SendTransaction", ctx, mock.Anything).
+			Return(nil, errInternal)
+
+		err := c.SendTransaction(ctx, *tx, client.WithoutValidation())
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	}))
 }
 
 func TestClient_GetTransaction(t *testing.T) {
@@ -402,6 +539,97 @@ func TestClient_GetTransactionResult(t *testing.T) {
 	}))
 }
 
+func TestClient_GetTransactionEvents(t *testing.T) {
+	results := test.TransactionResultGenerator()
+	ids := test.IdentifierGenerator()
+
+	t.Run("Transfer transaction", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		txID := ids.New()
+		expectedResult := results.New()
+		require.NotEmpty(t, expectedResult.Events)
+
+		response, _ := convert.TransactionResultToMessage(expectedResult)
+
+		rpc.On("GetTransactionResult", ctx, &access.GetTransactionRequest{Id: txID.Bytes()}).
+			Return(response, nil)
+
+		events, err := c.GetTransactionEvents(ctx, txID)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedResult.Events, events)
+	}))
+
+	t.Run("No events", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		txID := ids.New()
+		expectedResult := results.New()
+		expectedResult.Events = nil
+
+		response, _ := convert.TransactionResultToMessage(expectedResult)
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(response, nil)
+
+		events, err := c.GetTransactionEvents(ctx, txID)
+		require.NoError(t, err)
+
+		assert.NotNil(t, events)
+		assert.Empty(t, events)
+	}))
+
+	t.Run("Not found error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		txID := ids.New()
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(nil, errNotFound)
+
+		events, err := c.GetTransactionEvents(ctx, txID)
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Nil(t, events)
+	}))
+}
+
+func TestClient_GetAccountAtBlockID(t *testing.T) {
+	accounts := test.AccountGenerator()
+	blocks := test.BlockGenerator()
+
+	t.Run("Latest sealed block", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		expectedAccount := accounts.New()
+		sealedHeader := blocks.New().BlockHeader
+
+		msg, err := convert.BlockHeaderToMessage(sealedHeader)
+		require.NoError(t, err)
+
+		rpc.On("GetLatestBlockHeader", ctx, &access.GetLatestBlockHeaderRequest{IsSealed: true}).
+			Return(&access.BlockHeaderResponse{Block: msg}, nil)
+
+		rpc.On("GetBlockHeaderByID", ctx, &access.GetBlockHeaderByIDRequest{Id: sealedHeader.ID.Bytes()}).
+			Return(&access.BlockHeaderResponse{Block: msg}, nil)
+
+		rpc.On("GetAccountAtBlockHeight", ctx, &access.GetAccountAtBlockHeightRequest{
+			Address:     expectedAccount.Address.Bytes(),
+			BlockHeight: sealedHeader.Height,
+		}).Return(&access.AccountResponse{Account: convert.AccountToMessage(*expectedAccount)}, nil)
+
+		header, err := c.GetLatestBlockHeader(ctx, true)
+		require.NoError(t, err)
+
+		account, err := c.GetAccountAtBlockID(ctx, expectedAccount.Address, header.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedAccount, account)
+	}))
+
+	t.Run("Block not found", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetBlockHeaderByID", ctx, mock.Anything).
+			Return(nil, errNotFound)
+
+		account, err := c.GetAccountAtBlockID(ctx, accounts.New().Address, blocks.New().ID)
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Nil(t, account)
+	}))
+}
+
 func TestClient_GetAccountAtLatestBlock(t *testing.T) {
 	accounts := test.AccountGenerator()
 	addresses := test.AddressGenerator()
@@ -433,6 +661,135 @@ func TestClient_GetAccountAtLatestBlock(t *testing.T) {
 	}))
 }
 
+func TestClient_ValidateSignatureKey(t *testing.T) {
+	accounts := test.AccountGenerator()
+
+	t.Run("Valid key", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		account := accounts.New()
+		response := &access.AccountResponse{
+			Account: convert.AccountToMessage(*account),
+		}
+
+		rpc.On("GetAccountAtLatestBlock", ctx, mock.Anything).Return(response, nil)
+
+		key := account.Keys[0]
+
+		err := c.ValidateSignatureKey(ctx, account.Address, key.Index, key.SigAlgo)
+		assert.NoError(t, err)
+	}))
+
+	t.Run("Mismatched algorithm", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		account := accounts.New()
+		response := &access.AccountResponse{
+			Account: convert.AccountToMessage(*account),
+		}
+
+		rpc.On("GetAccountAtLatestBlock", ctx, mock.Anything).Return(response, nil)
+
+		key := account.Keys[0]
+		require.Equal(t, crypto.ECDSA_P256, key.SigAlgo)
+
+		err := c.ValidateSignatureKey(ctx, account.Address, key.Index, crypto.ECDSA_secp256k1)
+		assert.Error(t, err)
+	}))
+
+	t.Run("Revoked key", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		account := accounts.New()
+		account.Keys[0].Revoked = true
+
+		response := &access.AccountResponse{
+			Account: convert.AccountToMessage(*account),
+		}
+
+		rpc.On("GetAccountAtLatestBlock", ctx, mock.Anything).Return(response, nil)
+
+		key := account.Keys[0]
+
+		err := c.ValidateSignatureKey(ctx, account.Address, key.Index, key.SigAlgo)
+		assert.Error(t, err)
+	}))
+
+	t.Run("Missing key", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		account := accounts.New()
+		response := &access.AccountResponse{
+			Account: convert.AccountToMessage(*account),
+		}
+
+		rpc.On("GetAccountAtLatestBlock", ctx, mock.Anything).Return(response, nil)
+
+		err := c.ValidateSignatureKey(ctx, account.Address, 42, crypto.ECDSA_P256)
+		assert.Error(t, err)
+	}))
+}
+
+func TestClient_CanAffordTransaction(t *testing.T) {
+	accounts := test.AccountGenerator()
+
+	t.Run("Sufficient balance", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		payer := accounts.New()
+		payer.Balance = 100
+
+		response := &access.AccountResponse{
+			Account: convert.AccountToMessage(*payer),
+		}
+
+		rpc.On("GetAccountAtLatestBlock", ctx, mock.Anything).Return(response, nil)
+
+		tx := flow.NewTransaction().SetPayer(payer.Address)
+
+		ok, err := c.CanAffordTransaction(ctx, tx, cadence.UFix64(100))
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}))
+
+	t.Run("Underfunded payer", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		payer := accounts.New()
+		payer.Balance = 100
+
+		response := &access.AccountResponse{
+			Account: convert.AccountToMessage(*payer),
+		}
+
+		rpc.On("GetAccountAtLatestBlock", ctx, mock.Anything).Return(response, nil)
+
+		tx := flow.NewTransaction().SetPayer(payer.Address)
+
+		err := tx.AddArgument(cadence.UFix64(50))
+		require.NoError(t, err)
+
+		ok, err := c.CanAffordTransaction(ctx, tx, cadence.UFix64(60), client.WithTransferArgument(0))
+		assert.False(t, ok)
+
+		var balanceErr client.InsufficientBalanceError
+		require.True(t, errors.As(err, &balanceErr))
+		assert.Equal(t, uint64(110), balanceErr.Required)
+		assert.Equal(t, uint64(10), balanceErr.Shortfall)
+	}))
+
+	t.Run("Overflowing transfer argument", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		tx := flow.NewTransaction()
+
+		err := tx.AddArgument(cadence.UFix64(math.MaxUint64 - 10))
+		require.NoError(t, err)
+
+		ok, err := c.CanAffordTransaction(ctx, tx, cadence.UFix64(60), client.WithTransferArgument(0))
+		assert.False(t, ok)
+		assert.Error(t, err)
+		rpc.AssertNumberOfCalls(t, "GetAccountAtLatestBlock", 0)
+	}))
+
+	t.Run("Invalid transfer argument", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		tx := flow.NewTransaction()
+
+		err := tx.AddArgument(cadence.NewString("foo"))
+		require.NoError(t, err)
+
+		ok, err := c.CanAffordTransaction(ctx, tx, cadence.UFix64(60), client.WithTransferArgument(0))
+		assert.False(t, ok)
+		assert.Error(t, err)
+	}))
+}
+
 func TestClient_ExecuteScriptAtLatestBlock(t *testing.T) {
 	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
 		expectedValue := cadence.NewInt(42)
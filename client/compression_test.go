@@ -0,0 +1,94 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/portto/blocto-flow-go-sdk/client"
+)
+
+// pingServer is a stub Access API server that only implements Ping.
+type pingServer struct {
+	access.UnimplementedAccessAPIServer
+}
+
+func (s *pingServer) Ping(context.Context, *access.PingRequest) (*access.PingResponse, error) {
+	return &access.PingResponse{}, nil
+}
+
+// compressionRecorder is a server stats handler that records the compression of incoming requests.
+type compressionRecorder struct {
+	compressions chan string
+}
+
+func (r *compressionRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if header, ok := s.(*stats.InHeader); ok {
+		r.compressions <- header.Compression
+	}
+}
+
+func (r *compressionRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+func TestClient_WithCompression(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+
+	recorder := &compressionRecorder{compressions: make(chan string, 1)}
+
+	server := grpc.NewServer(grpc.StatsHandler(recorder))
+	access.RegisterAccessAPIServer(server, &pingServer{})
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	c, err := client.New(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(),
+		client.WithCompression("gzip"),
+	)
+	require.NoError(t, err)
+	defer c.Close()
+
+	err = c.Ping(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "gzip", <-recorder.compressions)
+}
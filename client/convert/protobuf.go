@@ -254,6 +254,7 @@ func MessageToCollection(m *entities.Collection) (flow.Collection, error) {
 func CollectionGuaranteeToMessage(g flow.CollectionGuarantee) *entities.CollectionGuarantee {
 	return &entities.CollectionGuarantee{
 		CollectionId: g.CollectionID.Bytes(),
+		Signatures:   g.Signatures,
 	}
 }
 
@@ -264,6 +265,7 @@ func MessageToCollectionGuarantee(m *entities.CollectionGuarantee) (flow.Collect
 
 	return flow.CollectionGuarantee{
 		CollectionID: flow.HashToID(m.CollectionId),
+		Signatures:   m.GetSignatures(),
 	}, nil
 }
 
@@ -408,36 +410,71 @@ func MessageToTransaction(m *entities.Transaction) (flow.Transaction, error) {
 
 	proposalKey := m.GetProposalKey()
 	if proposalKey != nil {
-		proposalAddress := flow.BytesToAddress(proposalKey.GetAddress())
+		proposalAddress, err := flow.NormalizeAddress(proposalKey.GetAddress())
+		if err != nil {
+			return flow.Transaction{}, fmt.Errorf("invalid proposal key address: %w", err)
+		}
 		t.SetProposalKey(proposalAddress, int(proposalKey.GetKeyId()), proposalKey.GetSequenceNumber())
 	}
 
 	payer := m.GetPayer()
 	if payer != nil {
-		t.SetPayer(
-			flow.BytesToAddress(payer),
-		)
+		payerAddress, err := flow.NormalizeAddress(payer)
+		if err != nil {
+			return flow.Transaction{}, fmt.Errorf("invalid payer address: %w", err)
+		}
+		t.SetPayer(payerAddress)
 	}
 
-	for _, authorizer := range m.GetAuthorizers() {
-		t.AddAuthorizer(
-			flow.BytesToAddress(authorizer),
-		)
+	for i, authorizer := range m.GetAuthorizers() {
+		authorizerAddress, err := flow.NormalizeAddress(authorizer)
+		if err != nil {
+			return flow.Transaction{}, fmt.Errorf("invalid authorizer address at index %d: %w", i, err)
+		}
+		t.AddAuthorizer(authorizerAddress)
 	}
 
-	for _, sig := range m.GetPayloadSignatures() {
-		addr := flow.BytesToAddress(sig.GetAddress())
+	for i, sig := range m.GetPayloadSignatures() {
+		addr, err := flow.NormalizeAddress(sig.GetAddress())
+		if err != nil {
+			return flow.Transaction{}, fmt.Errorf("invalid payload signature address at index %d: %w", i, err)
+		}
 		t.AddPayloadSignature(addr, int(sig.GetKeyId()), sig.GetSignature())
 	}
 
-	for _, sig := range m.GetEnvelopeSignatures() {
-		addr := flow.BytesToAddress(sig.GetAddress())
+	for i, sig := range m.GetEnvelopeSignatures() {
+		addr, err := flow.NormalizeAddress(sig.GetAddress())
+		if err != nil {
+			return flow.Transaction{}, fmt.Errorf("invalid envelope signature address at index %d: %w", i, err)
+		}
 		t.AddEnvelopeSignature(addr, int(sig.GetKeyId()), sig.GetSignature())
 	}
 
 	return *t, nil
 }
 
+// TransactionFromProto reconstructs a transaction from its protobuf message.
+//
+// Signer indexes are recomputed from the transaction signers, so the result can be
+// re-encoded and its ID computed without a client.
+func TransactionFromProto(m *entities.Transaction) (*flow.Transaction, error) {
+	t, err := MessageToTransaction(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// TransactionToProto converts a transaction to its protobuf message.
+func TransactionToProto(t *flow.Transaction) (*entities.Transaction, error) {
+	if t == nil {
+		return nil, errors.New("transaction is nil")
+	}
+
+	return TransactionToMessage(*t)
+}
+
 func TransactionResultToMessage(result flow.TransactionResult) (*access.TransactionResultResponse, error) {
 	eventMessages := make([]*entities.Event, len(result.Events))
 
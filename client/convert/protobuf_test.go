@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -149,6 +150,26 @@ func TestConvert_CollectionGuarantee(t *testing.T) {
 	assert.Equal(t, *cgA, cgB)
 }
 
+func TestConvert_BlockCollectionGuarantees(t *testing.T) {
+	guarantee := test.CollectionGuaranteeGenerator().New()
+
+	msg := &entities.Block{
+		Id:     test.IdentifierGenerator().New().Bytes(),
+		Height: 42,
+		CollectionGuarantees: []*entities.CollectionGuarantee{
+			{
+				CollectionId: guarantee.CollectionID.Bytes(),
+				Signatures:   guarantee.Signatures,
+			},
+		},
+	}
+
+	block, err := convert.MessageToBlock(msg)
+	require.NoError(t, err)
+
+	assert.Equal(t, []flow.CollectionGuarantee{*guarantee}, block.CollectionGuarantees())
+}
+
 func TestConvert_CollectionGuarantees(t *testing.T) {
 	cgs := test.CollectionGuaranteeGenerator()
 
@@ -229,6 +250,47 @@ func TestConvert_Transaction(t *testing.T) {
 	})
 }
 
+func TestConvert_TransactionFromProto(t *testing.T) {
+	proposer := flow.HexToAddress("01")
+	payer := flow.HexToAddress("02")
+
+	msg := &entities.Transaction{
+		Script:           []byte(`transaction { execute { log("Hello, World!") } }`),
+		Arguments:        [][]byte{[]byte(`{"type":"String","value":"foo"}`)},
+		ReferenceBlockId: flow.HexToID("f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b").Bytes(),
+		GasLimit:         42,
+		ProposalKey: &entities.Transaction_ProposalKey{
+			Address:        proposer.Bytes(),
+			KeyId:          3,
+			SequenceNumber: 7,
+		},
+		Payer:       payer.Bytes(),
+		Authorizers: [][]byte{proposer.Bytes()},
+		PayloadSignatures: []*entities.Transaction_Signature{
+			{Address: proposer.Bytes(), KeyId: 3, Signature: []byte{1}},
+		},
+		EnvelopeSignatures: []*entities.Transaction_Signature{
+			{Address: payer.Bytes(), KeyId: 0, Signature: []byte{2}},
+		},
+	}
+
+	tx, err := convert.TransactionFromProto(msg)
+	require.NoError(t, err)
+
+	assert.Equal(t, proposer, tx.ProposalKey.Address)
+	assert.Equal(t, payer, tx.Payer)
+	assert.Equal(t, []flow.Address{proposer}, tx.Authorizers)
+	assert.Equal(t, 1, tx.EnvelopeSignatures[0].SignerIndex)
+
+	roundTripped, err := convert.TransactionToProto(tx)
+	require.NoError(t, err)
+
+	assert.Equal(t, msg, roundTripped)
+
+	_, err = convert.TransactionFromProto(nil)
+	assert.Error(t, err)
+}
+
 func TestConvert_TransactionResult(t *testing.T) {
 	resultA := test.TransactionResultGenerator().New()
 
@@ -0,0 +1,69 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+
+	"github.com/portto/blocto-flow-go-sdk"
+	"github.com/portto/blocto-flow-go-sdk/client/convert"
+)
+
+// describeAddrPlaceholder is used in place of the access node address for clients
+// created from an RPC client.
+const describeAddrPlaceholder = "<access-node-address>"
+
+// DescribeSendTransaction returns a grpcurl command that sends the transaction to the access
+// node of this client, without sending it.
+//
+// The request is written as JSON with all byte fields base64-encoded, as expected by grpcurl.
+// The command assumes a plaintext connection; remove the -plaintext flag to connect over TLS.
+func (c *Client) DescribeSendTransaction(tx *flow.Transaction) string {
+	txMsg, err := convert.TransactionToMessage(*tx)
+	if err != nil {
+		return fmt.Sprintf("# failed to describe transaction: %s", err)
+	}
+
+	marshaler := jsonpb.Marshaler{OrigName: true}
+
+	req, err := marshaler.MarshalToString(&access.SendTransactionRequest{Transaction: txMsg})
+	if err != nil {
+		return fmt.Sprintf("# failed to describe transaction: %s", err)
+	}
+
+	addr := c.addr
+	if addr == "" {
+		addr = describeAddrPlaceholder
+	}
+
+	return fmt.Sprintf(
+		"grpcurl -plaintext -d %s %s flow.access.AccessAPI/SendTransaction",
+		shellQuote(req),
+		addr,
+	)
+}
+
+// shellQuote quotes a string as a single POSIX shell word.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
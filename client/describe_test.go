@@ -0,0 +1,48 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portto/blocto-flow-go-sdk/client"
+	"github.com/portto/blocto-flow-go-sdk/test"
+)
+
+func TestClient_DescribeSendTransaction(t *testing.T) {
+	transactions := test.TransactionGenerator()
+
+	t.Run("Signed transaction", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		tx := transactions.New()
+
+		cmd := c.DescribeSendTransaction(tx)
+
+		assert.Contains(t, cmd, "grpcurl -plaintext -d '")
+		assert.Contains(t, cmd, base64.StdEncoding.EncodeToString(tx.Script))
+		assert.Contains(t, cmd, base64.StdEncoding.EncodeToString(tx.Payer.Bytes()))
+		assert.Contains(t, cmd, base64.StdEncoding.EncodeToString(tx.EnvelopeSignatures[0].Signature))
+		assert.Contains(t, cmd, "flow.access.AccessAPI/SendTransaction")
+
+		rpc.AssertNumberOfCalls(t, "SendTransaction", 0)
+	}))
+}
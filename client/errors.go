@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"google.golang.org/grpc/status"
+
+	"github.com/portto/blocto-flow-go-sdk"
 )
 
 const errorMessagePrefix = "client: "
@@ -91,3 +93,36 @@ func (e MessageToEntityError) Error() string {
 func (e MessageToEntityError) Unwrap() error {
 	return e.Err
 }
+
+// An InsufficientBalanceError indicates that an account balance does not cover the
+// amount required by a transaction.
+type InsufficientBalanceError struct {
+	Address   flow.Address
+	Balance   uint64
+	Required  uint64
+	Shortfall uint64
+}
+
+func (e InsufficientBalanceError) Error() string {
+	return errorMessage(
+		"account %s has balance %d but %d is required (short by %d)",
+		e.Address,
+		e.Balance,
+		e.Required,
+		e.Shortfall,
+	)
+}
+
+// An InvalidTransactionError indicates that a transaction failed local validation
+// and was not submitted to the network.
+type InvalidTransactionError struct {
+	Err error
+}
+
+func (e InvalidTransactionError) Error() string {
+	return errorMessage("invalid transaction: %s", e.Err.Error())
+}
+
+func (e InvalidTransactionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,131 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// MaxEventHeightRange is the largest height range an access node accepts in a single
+// GetEventsForHeightRange request.
+const MaxEventHeightRange uint64 = 250
+
+// GetEventsForHeightRangeParallel retrieves events with the given type for all sealed
+// blocks between the start and end block heights (inclusive).
+//
+// The range is split into windows of at most MaxEventHeightRange blocks, which are queried
+// concurrently by the given number of workers. The results are returned in height order.
+// At most workers requests are in flight at any time.
+//
+// If any request fails, the remaining requests are cancelled and the first error is returned.
+func (c *Client) GetEventsForHeightRangeParallel(
+	ctx context.Context,
+	eventType string,
+	start uint64,
+	end uint64,
+	workers int,
+) ([]BlockEvents, error) {
+	if end < start {
+		return nil, errors.New(errorMessage("end height %d is below start height %d", end, start))
+	}
+
+	if workers < 1 {
+		return nil, errors.New(errorMessage("at least one worker is required, got %d", workers))
+	}
+
+	windows := make([]EventRangeQuery, 0)
+	for height := start; ; height += MaxEventHeightRange {
+		windowEnd := height + MaxEventHeightRange - 1
+		if windowEnd > end || windowEnd < height {
+			windowEnd = end
+		}
+
+		windows = append(windows, EventRangeQuery{
+			Type:        eventType,
+			StartHeight: height,
+			EndHeight:   windowEnd,
+		})
+
+		if windowEnd == end {
+			break
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]BlockEvents, len(windows))
+	indexes := make(chan int)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	for i := 0; i < workers && i < len(windows); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range indexes {
+				blockEvents, err := c.GetEventsForHeightRange(ctx, windows[index])
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+
+				results[index] = blockEvents
+			}
+		}()
+	}
+
+dispatch:
+	for i := range windows {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	events := make([]BlockEvents, 0)
+	for _, blockEvents := range results {
+		events = append(events, blockEvents...)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,94 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/portto/blocto-flow-go-sdk/client"
+)
+
+// eventsForHeightRange returns a response with an empty result for each height in the request.
+func eventsForHeightRange(
+	_ context.Context,
+	in *access.GetEventsForHeightRangeRequest,
+	_ ...grpc.CallOption,
+) *access.EventsResponse {
+	timestamp, _ := ptypes.TimestampProto(time.Unix(0, 0))
+
+	res := &access.EventsResponse{}
+	for height := in.GetStartHeight(); height <= in.GetEndHeight(); height++ {
+		res.Results = append(res.Results, &access.EventsResponse_Result{
+			BlockHeight:    height,
+			BlockTimestamp: timestamp,
+		})
+	}
+
+	return res
+}
+
+func TestClient_GetEventsForHeightRangeParallel(t *testing.T) {
+	t.Run("Several windows", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetEventsForHeightRange", mock.Anything, mock.Anything).
+			Return(eventsForHeightRange, nil)
+
+		start, end := uint64(100), uint64(700)
+
+		blockEvents, err := c.GetEventsForHeightRangeParallel(ctx, "flow.AccountCreated", start, end, 2)
+		require.NoError(t, err)
+
+		require.Len(t, blockEvents, int(end-start+1))
+		for i, events := range blockEvents {
+			assert.Equal(t, start+uint64(i), events.Height)
+		}
+
+		rpc.AssertNumberOfCalls(t, "GetEventsForHeightRange", 3)
+		rpc.AssertCalled(t, "GetEventsForHeightRange", mock.Anything, &access.GetEventsForHeightRangeRequest{
+			Type:        "flow.AccountCreated",
+			StartHeight: 600,
+			EndHeight:   700,
+		})
+	}))
+
+	t.Run("Error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetEventsForHeightRange", mock.Anything, mock.Anything).
+			Return(nil, errInternal)
+
+		blockEvents, err := c.GetEventsForHeightRangeParallel(ctx, "flow.AccountCreated", 0, 1000, 4)
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Nil(t, blockEvents)
+	}))
+
+	t.Run("Invalid range", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		_, err := c.GetEventsForHeightRangeParallel(ctx, "flow.AccountCreated", 10, 9, 1)
+		assert.Error(t, err)
+	}))
+}
@@ -0,0 +1,93 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/portto/blocto-flow-go-sdk"
+)
+
+// blockTimeSampleSize is the number of recent blocks used to estimate the average block time.
+const blockTimeSampleSize = 100
+
+// WillExpireBefore estimates whether a transaction will expire before the given time.
+//
+// A transaction expires once the network passes flow.DefaultTransactionExpiry blocks beyond
+// its reference block. The height of the network at the given time is estimated from the
+// average block time of recent sealed blocks, so the result is only an approximation: the
+// actual block rate can vary and a transaction close to the expiry window may expire
+// earlier or later than estimated.
+func (c *Client) WillExpireBefore(ctx context.Context, tx *flow.Transaction, t time.Time) (bool, error) {
+	refBlock, err := c.GetBlockHeaderByID(ctx, tx.ReferenceBlockID)
+	if err != nil {
+		return false, err
+	}
+
+	expiryHeight := refBlock.Height + flow.DefaultTransactionExpiry
+
+	latest, err := c.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		return false, err
+	}
+
+	if latest.Height > expiryHeight {
+		return true, nil
+	}
+
+	blockTime, err := c.averageBlockTime(ctx, latest)
+	if err != nil {
+		return false, err
+	}
+
+	elapsed := t.Sub(latest.Timestamp)
+	if elapsed <= 0 {
+		return false, nil
+	}
+
+	estimatedHeight := latest.Height + uint64(elapsed/blockTime)
+
+	return estimatedHeight > expiryHeight, nil
+}
+
+// averageBlockTime estimates the average time between the blocks preceding the given block.
+func (c *Client) averageBlockTime(ctx context.Context, latest *flow.BlockHeader) (time.Duration, error) {
+	if latest.Height == 0 {
+		return 0, errors.New(errorMessage("not enough blocks to estimate the block time"))
+	}
+
+	sampleHeight := uint64(0)
+	if latest.Height > blockTimeSampleSize {
+		sampleHeight = latest.Height - blockTimeSampleSize
+	}
+
+	sample, err := c.GetBlockHeaderByHeight(ctx, sampleHeight)
+	if err != nil {
+		return 0, err
+	}
+
+	blockTime := latest.Timestamp.Sub(sample.Timestamp) / time.Duration(latest.Height-sampleHeight)
+	if blockTime <= 0 {
+		return 0, errors.New(errorMessage("failed to estimate the block time"))
+	}
+
+	return blockTime, nil
+}
@@ -0,0 +1,83 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portto/blocto-flow-go-sdk"
+	"github.com/portto/blocto-flow-go-sdk/client"
+	"github.com/portto/blocto-flow-go-sdk/client/convert"
+	"github.com/portto/blocto-flow-go-sdk/test"
+)
+
+func blockHeaderResponse(t *testing.T, header flow.BlockHeader) *access.BlockHeaderResponse {
+	msg, err := convert.BlockHeaderToMessage(header)
+	require.NoError(t, err)
+
+	return &access.BlockHeaderResponse{Block: msg}
+}
+
+func TestClient_WillExpireBefore(t *testing.T) {
+	ids := test.IdentifierGenerator()
+
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	refBlock := flow.BlockHeader{ID: ids.New(), Height: 500, Timestamp: now.Add(-500 * time.Second)}
+
+	// blocks are produced once per second
+	latestBlock := flow.BlockHeader{ID: ids.New(), Height: 1000, Timestamp: now}
+	sampleBlock := flow.BlockHeader{ID: ids.New(), Height: 900, Timestamp: now.Add(-100 * time.Second)}
+
+	tx := flow.NewTransaction().SetReferenceBlockID(refBlock.ID)
+
+	mockBlocks := func(t *testing.T, ctx context.Context, rpc *MockRPCClient) {
+		rpc.On("GetBlockHeaderByID", ctx, mock.Anything).
+			Return(blockHeaderResponse(t, refBlock), nil)
+
+		rpc.On("GetLatestBlockHeader", ctx, mock.Anything).
+			Return(blockHeaderResponse(t, latestBlock), nil)
+
+		rpc.On("GetBlockHeaderByHeight", ctx, &access.GetBlockHeaderByHeightRequest{Height: sampleBlock.Height}).
+			Return(blockHeaderResponse(t, sampleBlock), nil)
+	}
+
+	t.Run("Valid at time", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		mockBlocks(t, ctx, rpc)
+
+		expires, err := c.WillExpireBefore(ctx, tx, now.Add(50*time.Second))
+		require.NoError(t, err)
+		assert.False(t, expires)
+	}))
+
+	t.Run("Expired at time", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		mockBlocks(t, ctx, rpc)
+
+		expires, err := c.WillExpireBefore(ctx, tx, now.Add(200*time.Second))
+		require.NoError(t, err)
+		assert.True(t, expires)
+	}))
+}
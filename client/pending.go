@@ -0,0 +1,99 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/portto/blocto-flow-go-sdk"
+)
+
+// A PendingTracker keeps track of submitted transactions that have not yet been sealed,
+// grouped by payer.
+//
+// The Access API does not index transactions by payer, so the tracker only knows about
+// transactions that are explicitly passed to Track.
+type PendingTracker struct {
+	client  *Client
+	opts    []WaitOption
+	mu      sync.RWMutex
+	pending map[flow.Address][]flow.Identifier
+}
+
+// NewPendingTracker returns a new tracker that waits for transaction results using the
+// given client.
+func NewPendingTracker(c *Client, opts ...WaitOption) *PendingTracker {
+	return &PendingTracker{
+		client:  c,
+		opts:    opts,
+		pending: make(map[flow.Address][]flow.Identifier),
+	}
+}
+
+// Track records a submitted transaction as pending for its payer.
+//
+// The transaction remains pending until WaitForSeal returns in the background, either
+// because the transaction is sealed or expired, or because its result could not be
+// fetched before the context was done.
+func (p *PendingTracker) Track(ctx context.Context, tx flow.Transaction) {
+	payer := tx.Payer
+	txID := tx.ID()
+
+	p.mu.Lock()
+	p.pending[payer] = append(p.pending[payer], txID)
+	p.mu.Unlock()
+
+	go func() {
+		_, _ = p.client.WaitForSeal(ctx, txID, p.opts...)
+		p.remove(payer, txID)
+	}()
+}
+
+// Pending returns the IDs of the tracked transactions for the given payer that have not
+// yet been sealed, in the order they were tracked.
+func (p *PendingTracker) Pending(payer flow.Address) []flow.Identifier {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	ids := make([]flow.Identifier, len(p.pending[payer]))
+	copy(ids, p.pending[payer])
+
+	return ids
+}
+
+func (p *PendingTracker) remove(payer flow.Address, txID flow.Identifier) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ids := p.pending[payer]
+	for i, id := range ids {
+		if id == txID {
+			ids = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+
+	if len(ids) == 0 {
+		delete(p.pending, payer)
+		return
+	}
+
+	p.pending[payer] = ids
+}
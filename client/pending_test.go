@@ -0,0 +1,75 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/portto/blocto-flow-go-sdk"
+	"github.com/portto/blocto-flow-go-sdk/client"
+	"github.com/portto/blocto-flow-go-sdk/test"
+)
+
+func TestPendingTracker(t *testing.T) {
+	addresses := test.AddressGenerator()
+	transactions := test.TransactionGenerator()
+
+	t.Run("Two pending, one sealed", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		payer := addresses.New()
+
+		sealedTx := transactions.New().SetPayer(payer)
+		pendingTx := transactions.New().SetPayer(payer)
+		// generated transactions are identical, so change one to get a distinct ID
+		pendingTx.SetGasLimit(sealedTx.GasLimit + 1)
+
+		forTransaction := func(tx *flow.Transaction) interface{} {
+			return mock.MatchedBy(func(req *access.GetTransactionRequest) bool {
+				return bytes.Equal(req.Id, tx.ID().Bytes())
+			})
+		}
+
+		rpc.On("GetTransactionResult", mock.Anything, forTransaction(sealedTx)).
+			Return(transactionResultResponse(flow.TransactionStatusSealed), nil)
+
+		rpc.On("GetTransactionResult", mock.Anything, forTransaction(pendingTx)).
+			Return(transactionResultResponse(flow.TransactionStatusPending), nil)
+
+		tracker := client.NewPendingTracker(c, client.WithPollInterval(testPollInterval))
+
+		tracker.Track(ctx, *sealedTx)
+		tracker.Track(ctx, *pendingTx)
+
+		assert.Eventually(t, func() bool {
+			return len(tracker.Pending(payer)) == 1
+		}, time.Second, testPollInterval)
+
+		assert.Equal(t, []flow.Identifier{pendingTx.ID()}, tracker.Pending(payer))
+		assert.Empty(t, tracker.Pending(addresses.New()))
+	}))
+}
@@ -0,0 +1,71 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/portto/blocto-flow-go-sdk"
+)
+
+// PrepareTransaction sets the reference block and proposal sequence number of a
+// transaction from the current state of the network.
+//
+// The reference block is set to the latest sealed block. If the proposal key of the
+// transaction is set, its sequence number is set to the current sequence number of the
+// referenced key on the proposer account; otherwise the proposal key is left unchanged.
+//
+// The transaction is not modified if an error is returned.
+func (c *Client) PrepareTransaction(ctx context.Context, tx *flow.Transaction) error {
+	header, err := c.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	if tx.ProposalKey.Address != flow.EmptyAddress {
+		seq, err := c.getKeySequenceNumber(ctx, tx.ProposalKey.Address, tx.ProposalKey.KeyIndex)
+		if err != nil {
+			return err
+		}
+
+		tx.ProposalKey.SequenceNumber = seq
+	}
+
+	tx.SetReferenceBlockID(header.ID)
+
+	return nil
+}
+
+// getKeySequenceNumber returns the current sequence number of the key with the given
+// index on an account.
+func (c *Client) getKeySequenceNumber(ctx context.Context, address flow.Address, keyIndex int) (uint64, error) {
+	account, err := c.GetAccountAtLatestBlock(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range account.Keys {
+		if key.Index == keyIndex {
+			return key.SequenceNumber, nil
+		}
+	}
+
+	return 0, errors.New(errorMessage("account %s does not have a key with index %d", address, keyIndex))
+}
@@ -0,0 +1,112 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/portto/blocto-flow-go-sdk"
+	"github.com/portto/blocto-flow-go-sdk/client"
+	"github.com/portto/blocto-flow-go-sdk/client/convert"
+	"github.com/portto/blocto-flow-go-sdk/test"
+)
+
+func TestClient_PrepareTransaction(t *testing.T) {
+	accounts := test.AccountGenerator()
+	blocks := test.BlockGenerator()
+
+	accountResponse := func(account *flow.Account) *access.AccountResponse {
+		return &access.AccountResponse{Account: convert.AccountToMessage(*account)}
+	}
+
+	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		header := blocks.New().BlockHeader
+
+		account := accounts.New()
+		account.Keys[0].SequenceNumber = 42
+
+		rpc.On("GetLatestBlockHeader", ctx, &access.GetLatestBlockHeaderRequest{IsSealed: true}).
+			Return(blockHeaderResponse(t, header), nil)
+
+		rpc.On("GetAccountAtLatestBlock", ctx, &access.GetAccountAtLatestBlockRequest{
+			Address: account.Address.Bytes(),
+		}).Return(accountResponse(account), nil)
+
+		tx := flow.NewTransaction().SetProposalKey(account.Address, account.Keys[0].Index, 0)
+
+		err := c.PrepareTransaction(ctx, tx)
+		require.NoError(t, err)
+
+		assert.Equal(t, header.ID, tx.ReferenceBlockID)
+		assert.Equal(t, uint64(42), tx.ProposalKey.SequenceNumber)
+	}))
+
+	t.Run("No proposal key", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		header := blocks.New().BlockHeader
+
+		rpc.On("GetLatestBlockHeader", ctx, mock.Anything).
+			Return(blockHeaderResponse(t, header), nil)
+
+		tx := flow.NewTransaction()
+
+		err := c.PrepareTransaction(ctx, tx)
+		require.NoError(t, err)
+
+		assert.Equal(t, header.ID, tx.ReferenceBlockID)
+		assert.Equal(t, flow.ProposalKey{}, tx.ProposalKey)
+		rpc.AssertNumberOfCalls(t, "GetAccountAtLatestBlock", 0)
+	}))
+
+	t.Run("Missing key", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		header := blocks.New().BlockHeader
+		account := accounts.New()
+
+		rpc.On("GetLatestBlockHeader", ctx, mock.Anything).
+			Return(blockHeaderResponse(t, header), nil)
+
+		rpc.On("GetAccountAtLatestBlock", ctx, mock.Anything).
+			Return(accountResponse(account), nil)
+
+		tx := flow.NewTransaction().SetProposalKey(account.Address, 5, 0)
+
+		err := c.PrepareTransaction(ctx, tx)
+		assert.Error(t, err)
+		assert.True(t, tx.ReferenceBlockID.IsZero())
+	}))
+
+	t.Run("Internal error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetLatestBlockHeader", ctx, mock.Anything).
+			Return(nil, errInternal)
+
+		tx := flow.NewTransaction()
+
+		err := c.PrepareTransaction(ctx, tx)
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.True(t, tx.ReferenceBlockID.IsZero())
+	}))
+}
@@ -0,0 +1,472 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// An rpcRecord is a single recorded RPC call.
+//
+// Requests and responses are stored as binary protobuf messages, and errors are
+// stored as gRPC status codes and messages.
+type rpcRecord struct {
+	Method       string     `json:"method"`
+	Request      []byte     `json:"request"`
+	Response     []byte     `json:"response,omitempty"`
+	ErrorCode    codes.Code `json:"errorCode,omitempty"`
+	ErrorMessage string     `json:"errorMessage,omitempty"`
+}
+
+func (r rpcRecord) key() string {
+	return r.Method + "/" + hex.EncodeToString(r.Request)
+}
+
+// A RecordingClient is an RPCClient that forwards all calls to an underlying RPC client and
+// writes each request/response pair to an io.Writer.
+//
+// The recorded output can be served back with a ReplayClient to run deterministic tests
+// without a live Access API.
+//
+// A failure to record a call does not affect the result of the call. The first recording
+// error is returned by Err.
+type RecordingClient struct {
+	rpcClient RPCClient
+	mut       sync.Mutex
+	enc       *json.Encoder
+	err       error
+}
+
+var _ RPCClient = &RecordingClient{}
+
+// NewRecordingClient initializes a recording client that wraps the given RPC client
+// and writes its recordings to w.
+func NewRecordingClient(rpcClient RPCClient, w io.Writer) *RecordingClient {
+	return &RecordingClient{
+		rpcClient: rpcClient,
+		enc:       json.NewEncoder(w),
+	}
+}
+
+// Err returns the first error encountered while recording a call, or nil if all calls
+// have been recorded.
+func (c *RecordingClient) Err() error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.err
+}
+
+// record writes a call to the recording, keeping the error if it is the first to fail.
+func (c *RecordingClient) record(method string, req proto.Message, res proto.Message, rpcErr error) {
+	record, err := newRPCRecord(method, req, res, rpcErr)
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if err == nil {
+		err = c.enc.Encode(record)
+	}
+
+	if err != nil && c.err == nil {
+		c.err = err
+	}
+}
+
+func newRPCRecord(method string, req proto.Message, res proto.Message, rpcErr error) (rpcRecord, error) {
+	reqBytes, err := proto.Marshal(req)
+	if err != nil {
+		return rpcRecord{}, fmt.Errorf("client: failed to record %s request: %w", method, err)
+	}
+
+	record := rpcRecord{
+		Method:  method,
+		Request: reqBytes,
+	}
+
+	if rpcErr != nil {
+		s, _ := status.FromError(rpcErr)
+		record.ErrorCode = s.Code()
+		record.ErrorMessage = s.Message()
+	} else {
+		record.Response, err = proto.Marshal(res)
+		if err != nil {
+			return rpcRecord{}, fmt.Errorf("client: failed to record %s response: %w", method, err)
+		}
+	}
+
+	return record, nil
+}
+
+func (c *RecordingClient) Ping(ctx context.Context, in *access.PingRequest, opts ...grpc.CallOption) (*access.PingResponse, error) {
+	res, err := c.rpcClient.Ping(ctx, in, opts...)
+	c.record("Ping", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetLatestBlockHeader(ctx context.Context, in *access.GetLatestBlockHeaderRequest, opts ...grpc.CallOption) (*access.BlockHeaderResponse, error) {
+	res, err := c.rpcClient.GetLatestBlockHeader(ctx, in, opts...)
+	c.record("GetLatestBlockHeader", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetBlockHeaderByID(ctx context.Context, in *access.GetBlockHeaderByIDRequest, opts ...grpc.CallOption) (*access.BlockHeaderResponse, error) {
+	res, err := c.rpcClient.GetBlockHeaderByID(ctx, in, opts...)
+	c.record("GetBlockHeaderByID", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetBlockHeaderByHeight(ctx context.Context, in *access.GetBlockHeaderByHeightRequest, opts ...grpc.CallOption) (*access.BlockHeaderResponse, error) {
+	res, err := c.rpcClient.GetBlockHeaderByHeight(ctx, in, opts...)
+	c.record("GetBlockHeaderByHeight", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetLatestBlock(ctx context.Context, in *access.GetLatestBlockRequest, opts ...grpc.CallOption) (*access.BlockResponse, error) {
+	res, err := c.rpcClient.GetLatestBlock(ctx, in, opts...)
+	c.record("GetLatestBlock", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetBlockByID(ctx context.Context, in *access.GetBlockByIDRequest, opts ...grpc.CallOption) (*access.BlockResponse, error) {
+	res, err := c.rpcClient.GetBlockByID(ctx, in, opts...)
+	c.record("GetBlockByID", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetBlockByHeight(ctx context.Context, in *access.GetBlockByHeightRequest, opts ...grpc.CallOption) (*access.BlockResponse, error) {
+	res, err := c.rpcClient.GetBlockByHeight(ctx, in, opts...)
+	c.record("GetBlockByHeight", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetCollectionByID(ctx context.Context, in *access.GetCollectionByIDRequest, opts ...grpc.CallOption) (*access.CollectionResponse, error) {
+	res, err := c.rpcClient.GetCollectionByID(ctx, in, opts...)
+	c.record("GetCollectionByID", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) SendTransaction(ctx context.Context, in *access.SendTransactionRequest, opts ...grpc.CallOption) (*access.SendTransactionResponse, error) {
+	res, err := c.rpcClient.SendTransaction(ctx, in, opts...)
+	c.record("SendTransaction", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetTransaction(ctx context.Context, in *access.GetTransactionRequest, opts ...grpc.CallOption) (*access.TransactionResponse, error) {
+	res, err := c.rpcClient.GetTransaction(ctx, in, opts...)
+	c.record("GetTransaction", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetTransactionResult(ctx context.Context, in *access.GetTransactionRequest, opts ...grpc.CallOption) (*access.TransactionResultResponse, error) {
+	res, err := c.rpcClient.GetTransactionResult(ctx, in, opts...)
+	c.record("GetTransactionResult", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetAccount(ctx context.Context, in *access.GetAccountRequest, opts ...grpc.CallOption) (*access.GetAccountResponse, error) {
+	res, err := c.rpcClient.GetAccount(ctx, in, opts...)
+	c.record("GetAccount", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetAccountAtLatestBlock(ctx context.Context, in *access.GetAccountAtLatestBlockRequest, opts ...grpc.CallOption) (*access.AccountResponse, error) {
+	res, err := c.rpcClient.GetAccountAtLatestBlock(ctx, in, opts...)
+	c.record("GetAccountAtLatestBlock", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetAccountAtBlockHeight(ctx context.Context, in *access.GetAccountAtBlockHeightRequest, opts ...grpc.CallOption) (*access.AccountResponse, error) {
+	res, err := c.rpcClient.GetAccountAtBlockHeight(ctx, in, opts...)
+	c.record("GetAccountAtBlockHeight", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) ExecuteScriptAtLatestBlock(ctx context.Context, in *access.ExecuteScriptAtLatestBlockRequest, opts ...grpc.CallOption) (*access.ExecuteScriptResponse, error) {
+	res, err := c.rpcClient.ExecuteScriptAtLatestBlock(ctx, in, opts...)
+	c.record("ExecuteScriptAtLatestBlock", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) ExecuteScriptAtBlockID(ctx context.Context, in *access.ExecuteScriptAtBlockIDRequest, opts ...grpc.CallOption) (*access.ExecuteScriptResponse, error) {
+	res, err := c.rpcClient.ExecuteScriptAtBlockID(ctx, in, opts...)
+	c.record("ExecuteScriptAtBlockID", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) ExecuteScriptAtBlockHeight(ctx context.Context, in *access.ExecuteScriptAtBlockHeightRequest, opts ...grpc.CallOption) (*access.ExecuteScriptResponse, error) {
+	res, err := c.rpcClient.ExecuteScriptAtBlockHeight(ctx, in, opts...)
+	c.record("ExecuteScriptAtBlockHeight", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetEventsForHeightRange(ctx context.Context, in *access.GetEventsForHeightRangeRequest, opts ...grpc.CallOption) (*access.EventsResponse, error) {
+	res, err := c.rpcClient.GetEventsForHeightRange(ctx, in, opts...)
+	c.record("GetEventsForHeightRange", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetEventsForBlockIDs(ctx context.Context, in *access.GetEventsForBlockIDsRequest, opts ...grpc.CallOption) (*access.EventsResponse, error) {
+	res, err := c.rpcClient.GetEventsForBlockIDs(ctx, in, opts...)
+	c.record("GetEventsForBlockIDs", in, res, err)
+	return res, err
+}
+
+func (c *RecordingClient) GetNetworkParameters(ctx context.Context, in *access.GetNetworkParametersRequest, opts ...grpc.CallOption) (*access.GetNetworkParametersResponse, error) {
+	res, err := c.rpcClient.GetNetworkParameters(ctx, in, opts...)
+	c.record("GetNetworkParameters", in, res, err)
+	return res, err
+}
+
+// A ReplayClient is an RPCClient that serves responses previously captured by a RecordingClient.
+//
+// Calls are matched by method name and request message. If the same request was recorded
+// more than once, the responses are served in recording order and the last response is
+// repeated once the recording is exhausted.
+type ReplayClient struct {
+	mut     sync.Mutex
+	records map[string][]rpcRecord
+}
+
+var _ RPCClient = &ReplayClient{}
+
+// NewReplayClient initializes a replay client from recordings read from r.
+//
+// An error is returned if the recordings cannot be decoded.
+func NewReplayClient(r io.Reader) (*ReplayClient, error) {
+	records := make(map[string][]rpcRecord)
+
+	dec := json.NewDecoder(r)
+	for {
+		var record rpcRecord
+
+		err := dec.Decode(&record)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("client: failed to decode recording: %w", err)
+		}
+
+		records[record.key()] = append(records[record.key()], record)
+	}
+
+	return &ReplayClient{
+		records: records,
+	}, nil
+}
+
+func (c *ReplayClient) replay(method string, req proto.Message, res proto.Message) error {
+	reqBytes, err := proto.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("client: failed to encode %s request: %w", method, err)
+	}
+
+	key := rpcRecord{Method: method, Request: reqBytes}.key()
+
+	c.mut.Lock()
+	records := c.records[key]
+	if len(records) == 0 {
+		c.mut.Unlock()
+		return fmt.Errorf("client: no recorded response for %s", method)
+	}
+
+	record := records[0]
+	if len(records) > 1 {
+		c.records[key] = records[1:]
+	}
+	c.mut.Unlock()
+
+	if record.ErrorCode != codes.OK {
+		return status.Error(record.ErrorCode, record.ErrorMessage)
+	}
+
+	return proto.Unmarshal(record.Response, res)
+}
+
+func (c *ReplayClient) Ping(ctx context.Context, in *access.PingRequest, opts ...grpc.CallOption) (*access.PingResponse, error) {
+	res := &access.PingResponse{}
+	if err := c.replay("Ping", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetLatestBlockHeader(ctx context.Context, in *access.GetLatestBlockHeaderRequest, opts ...grpc.CallOption) (*access.BlockHeaderResponse, error) {
+	res := &access.BlockHeaderResponse{}
+	if err := c.replay("GetLatestBlockHeader", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetBlockHeaderByID(ctx context.Context, in *access.GetBlockHeaderByIDRequest, opts ...grpc.CallOption) (*access.BlockHeaderResponse, error) {
+	res := &access.BlockHeaderResponse{}
+	if err := c.replay("GetBlockHeaderByID", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetBlockHeaderByHeight(ctx context.Context, in *access.GetBlockHeaderByHeightRequest, opts ...grpc.CallOption) (*access.BlockHeaderResponse, error) {
+	res := &access.BlockHeaderResponse{}
+	if err := c.replay("GetBlockHeaderByHeight", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetLatestBlock(ctx context.Context, in *access.GetLatestBlockRequest, opts ...grpc.CallOption) (*access.BlockResponse, error) {
+	res := &access.BlockResponse{}
+	if err := c.replay("GetLatestBlock", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetBlockByID(ctx context.Context, in *access.GetBlockByIDRequest, opts ...grpc.CallOption) (*access.BlockResponse, error) {
+	res := &access.BlockResponse{}
+	if err := c.replay("GetBlockByID", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetBlockByHeight(ctx context.Context, in *access.GetBlockByHeightRequest, opts ...grpc.CallOption) (*access.BlockResponse, error) {
+	res := &access.BlockResponse{}
+	if err := c.replay("GetBlockByHeight", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetCollectionByID(ctx context.Context, in *access.GetCollectionByIDRequest, opts ...grpc.CallOption) (*access.CollectionResponse, error) {
+	res := &access.CollectionResponse{}
+	if err := c.replay("GetCollectionByID", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) SendTransaction(ctx context.Context, in *access.SendTransactionRequest, opts ...grpc.CallOption) (*access.SendTransactionResponse, error) {
+	res := &access.SendTransactionResponse{}
+	if err := c.replay("SendTransaction", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetTransaction(ctx context.Context, in *access.GetTransactionRequest, opts ...grpc.CallOption) (*access.TransactionResponse, error) {
+	res := &access.TransactionResponse{}
+	if err := c.replay("GetTransaction", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetTransactionResult(ctx context.Context, in *access.GetTransactionRequest, opts ...grpc.CallOption) (*access.TransactionResultResponse, error) {
+	res := &access.TransactionResultResponse{}
+	if err := c.replay("GetTransactionResult", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetAccount(ctx context.Context, in *access.GetAccountRequest, opts ...grpc.CallOption) (*access.GetAccountResponse, error) {
+	res := &access.GetAccountResponse{}
+	if err := c.replay("GetAccount", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetAccountAtLatestBlock(ctx context.Context, in *access.GetAccountAtLatestBlockRequest, opts ...grpc.CallOption) (*access.AccountResponse, error) {
+	res := &access.AccountResponse{}
+	if err := c.replay("GetAccountAtLatestBlock", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetAccountAtBlockHeight(ctx context.Context, in *access.GetAccountAtBlockHeightRequest, opts ...grpc.CallOption) (*access.AccountResponse, error) {
+	res := &access.AccountResponse{}
+	if err := c.replay("GetAccountAtBlockHeight", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) ExecuteScriptAtLatestBlock(ctx context.Context, in *access.ExecuteScriptAtLatestBlockRequest, opts ...grpc.CallOption) (*access.ExecuteScriptResponse, error) {
+	res := &access.ExecuteScriptResponse{}
+	if err := c.replay("ExecuteScriptAtLatestBlock", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) ExecuteScriptAtBlockID(ctx context.Context, in *access.ExecuteScriptAtBlockIDRequest, opts ...grpc.CallOption) (*access.ExecuteScriptResponse, error) {
+	res := &access.ExecuteScriptResponse{}
+	if err := c.replay("ExecuteScriptAtBlockID", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) ExecuteScriptAtBlockHeight(ctx context.Context, in *access.ExecuteScriptAtBlockHeightRequest, opts ...grpc.CallOption) (*access.ExecuteScriptResponse, error) {
+	res := &access.ExecuteScriptResponse{}
+	if err := c.replay("ExecuteScriptAtBlockHeight", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetEventsForHeightRange(ctx context.Context, in *access.GetEventsForHeightRangeRequest, opts ...grpc.CallOption) (*access.EventsResponse, error) {
+	res := &access.EventsResponse{}
+	if err := c.replay("GetEventsForHeightRange", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetEventsForBlockIDs(ctx context.Context, in *access.GetEventsForBlockIDsRequest, opts ...grpc.CallOption) (*access.EventsResponse, error) {
+	res := &access.EventsResponse{}
+	if err := c.replay("GetEventsForBlockIDs", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *ReplayClient) GetNetworkParameters(ctx context.Context, in *access.GetNetworkParametersRequest, opts ...grpc.CallOption) (*access.GetNetworkParametersResponse, error) {
+	res := &access.GetNetworkParametersResponse{}
+	if err := c.replay("GetNetworkParameters", in, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,130 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/portto/blocto-flow-go-sdk/client"
+	"github.com/portto/blocto-flow-go-sdk/client/convert"
+	"github.com/portto/blocto-flow-go-sdk/test"
+)
+
+func TestClient_RecordAndReplay(t *testing.T) {
+	accounts := test.AccountGenerator()
+
+	ctx := context.Background()
+
+	expectedAccount := accounts.New()
+	missingAddress := accounts.New().Address
+
+	rpc := &MockRPCClient{}
+
+	rpc.On("GetAccountAtLatestBlock", ctx, &access.GetAccountAtLatestBlockRequest{
+		Address: expectedAccount.Address.Bytes(),
+	}).Return(&access.AccountResponse{
+		Account: convert.AccountToMessage(*expectedAccount),
+	}, nil)
+
+	rpc.On("GetAccountAtLatestBlock", ctx, &access.GetAccountAtLatestBlockRequest{
+		Address: missingAddress.Bytes(),
+	}).Return(nil, errNotFound)
+
+	var recording bytes.Buffer
+
+	recordingClient := client.NewRecordingClient(rpc, &recording)
+	recorder := client.NewFromRPCClient(recordingClient)
+
+	account, err := recorder.GetAccount(ctx, expectedAccount.Address)
+	require.NoError(t, err)
+	assert.Equal(t, expectedAccount, account)
+
+	_, err = recorder.GetAccount(ctx, missingAddress)
+	require.Error(t, err)
+
+	rpc.AssertExpectations(t)
+	assert.NoError(t, recordingClient.Err())
+
+	replayClient, err := client.NewReplayClient(&recording)
+	require.NoError(t, err)
+
+	replayer := client.NewFromRPCClient(replayClient)
+
+	t.Run("Recorded response", func(t *testing.T) {
+		account, err := replayer.GetAccount(ctx, expectedAccount.Address)
+		require.NoError(t, err)
+		assert.Equal(t, expectedAccount, account)
+	})
+
+	t.Run("Recorded error", func(t *testing.T) {
+		account, err := replayer.GetAccount(ctx, missingAddress)
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Nil(t, account)
+	})
+
+	t.Run("Missing recording", func(t *testing.T) {
+		header, err := replayer.GetLatestBlockHeader(ctx, true)
+		assert.Error(t, err)
+		assert.Nil(t, header)
+	})
+
+	rpc.AssertNotCalled(t, "GetLatestBlockHeader", mock.Anything, mock.Anything)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestRecordingClient_WriteError(t *testing.T) {
+	accounts := test.AccountGenerator()
+
+	ctx := context.Background()
+
+	expectedAccount := accounts.New()
+
+	rpc := &MockRPCClient{}
+
+	rpc.On("GetAccountAtLatestBlock", ctx, mock.Anything).
+		Return(&access.AccountResponse{
+			Account: convert.AccountToMessage(*expectedAccount),
+		}, nil)
+
+	recordingClient := client.NewRecordingClient(rpc, failingWriter{})
+
+	account, err := client.NewFromRPCClient(recordingClient).GetAccount(ctx, expectedAccount.Address)
+	require.NoError(t, err)
+	assert.Equal(t, expectedAccount, account)
+
+	err = recordingClient.Err()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+}
@@ -0,0 +1,87 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/portto/blocto-flow-go-sdk"
+)
+
+// PrimeSequenceNumbers fetches the current sequence number of the first key of each of
+// the given accounts.
+//
+// The accounts are fetched concurrently. If any account cannot be fetched, the first
+// error encountered is returned.
+//
+// The result can be used to seed sequence number managers with flow.NewSequenceNumberManagers.
+func (c *Client) PrimeSequenceNumbers(ctx context.Context, addresses []flow.Address) (map[flow.Address]uint64, error) {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	seqs := make(map[flow.Address]uint64, len(addresses))
+
+	for _, address := range addresses {
+		wg.Add(1)
+
+		go func(address flow.Address) {
+			defer wg.Done()
+
+			seq, err := c.getSequenceNumber(ctx, address)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+
+			seqs[address] = seq
+		}(address)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return seqs, nil
+}
+
+func (c *Client) getSequenceNumber(ctx context.Context, address flow.Address) (uint64, error) {
+	account, err := c.GetAccountAtLatestBlock(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(account.Keys) == 0 {
+		return 0, errors.New(errorMessage("account %s does not have any keys", address))
+	}
+
+	return account.Keys[0].SequenceNumber, nil
+}
@@ -0,0 +1,79 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/portto/blocto-flow-go-sdk"
+	"github.com/portto/blocto-flow-go-sdk/client"
+	"github.com/portto/blocto-flow-go-sdk/client/convert"
+	"github.com/portto/blocto-flow-go-sdk/test"
+)
+
+func TestClient_PrimeSequenceNumbers(t *testing.T) {
+	accounts := test.AccountGenerator()
+
+	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		accountA := accounts.New()
+		accountB := accounts.New()
+
+		accountB.Keys[0].SequenceNumber = 7
+
+		for _, account := range []*flow.Account{accountA, accountB} {
+			rpc.On("GetAccountAtLatestBlock", mock.Anything, &access.GetAccountAtLatestBlockRequest{
+				Address: account.Address.Bytes(),
+			}).Return(&access.AccountResponse{
+				Account: convert.AccountToMessage(*account),
+			}, nil)
+		}
+
+		seqs, err := c.PrimeSequenceNumbers(ctx, []flow.Address{accountA.Address, accountB.Address})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[flow.Address]uint64{
+			accountA.Address: accountA.Keys[0].SequenceNumber,
+			accountB.Address: 7,
+		}, seqs)
+
+		managers := flow.NewSequenceNumberManagers(seqs)
+		assert.Equal(t, uint64(7), managers[accountB.Address].Next())
+		assert.Equal(t, uint64(8), managers[accountB.Address].Next())
+	}))
+
+	t.Run("Not found error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		account := accounts.New()
+
+		rpc.On("GetAccountAtLatestBlock", mock.Anything, mock.Anything).
+			Return(nil, errNotFound)
+
+		seqs, err := c.PrimeSequenceNumbers(ctx, []flow.Address{account.Address})
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Nil(t, seqs)
+	}))
+}
@@ -0,0 +1,69 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/portto/blocto-flow-go-sdk"
+)
+
+// A SporkID identifies a spork of the Flow network, such as "mainnet-5".
+//
+// Each spork is served by its own access nodes, and a transaction submitted to a spork
+// must reference a block from that spork.
+type SporkID string
+
+// SetSporkClient routes requests for the given spork to another client.
+//
+// The spork client is typically connected to an access node of a past spork. Setting a
+// nil client removes the route.
+func (c *Client) SetSporkClient(spork SporkID, sporkClient *Client) {
+	c.sporksMu.Lock()
+	defer c.sporksMu.Unlock()
+
+	if sporkClient == nil {
+		delete(c.sporks, spork)
+		return
+	}
+
+	if c.sporks == nil {
+		c.sporks = make(map[SporkID]*Client)
+	}
+
+	c.sporks[spork] = sporkClient
+}
+
+// LatestReferenceBlockForSpork gets the latest sealed block of the given spork, for use as
+// the reference block of a transaction submitted to that spork.
+//
+// The block is fetched from the client set for the spork with SetSporkClient. This
+// function returns an error if no client is set for the spork.
+func (c *Client) LatestReferenceBlockForSpork(ctx context.Context, spork SporkID) (*flow.Block, error) {
+	c.sporksMu.RLock()
+	sporkClient, ok := c.sporks[spork]
+	c.sporksMu.RUnlock()
+
+	if !ok {
+		return nil, errors.New(errorMessage("no access node configured for spork %q", spork))
+	}
+
+	return sporkClient.GetLatestBlock(ctx, true)
+}
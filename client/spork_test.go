@@ -0,0 +1,65 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portto/blocto-flow-go-sdk/client"
+	"github.com/portto/blocto-flow-go-sdk/client/convert"
+	"github.com/portto/blocto-flow-go-sdk/test"
+)
+
+func TestClient_LatestReferenceBlockForSpork(t *testing.T) {
+	blocks := test.BlockGenerator()
+
+	const spork client.SporkID = "mainnet-5"
+
+	t.Run("Configured spork", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		expectedBlock := blocks.New()
+
+		msg, err := convert.BlockToMessage(*expectedBlock)
+		require.NoError(t, err)
+
+		sporkRPC := &MockRPCClient{}
+		sporkRPC.On("GetLatestBlock", ctx, &access.GetLatestBlockRequest{IsSealed: true}).
+			Return(&access.BlockResponse{Block: msg}, nil)
+
+		c.SetSporkClient(spork, client.NewFromRPCClient(sporkRPC))
+
+		block, err := c.LatestReferenceBlockForSpork(ctx, spork)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedBlock.ID, block.ID)
+		sporkRPC.AssertExpectations(t)
+		rpc.AssertNotCalled(t, "GetLatestBlock", mock.Anything, mock.Anything)
+	}))
+
+	t.Run("Unknown spork", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		block, err := c.LatestReferenceBlockForSpork(ctx, spork)
+		assert.Error(t, err)
+		assert.Nil(t, block)
+	}))
+}
@@ -0,0 +1,67 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onflow/cadence"
+
+	"github.com/portto/blocto-flow-go-sdk"
+)
+
+const getAccountStorageScript = `
+pub fun main(address: Address): [UInt64] {
+  let account = getAccount(address)
+  return [account.storageUsed, account.storageCapacity]
+}
+`
+
+// GetAccountStorage gets the used storage and storage capacity of an account, in bytes.
+//
+// The values are read by executing a script against the latest sealed block. The script only
+// relies on built-in account fields, so the same script is used on every network.
+func (c *Client) GetAccountStorage(ctx context.Context, address flow.Address) (used, capacity uint64, err error) {
+	value, err := c.ExecuteScriptAtLatestBlock(
+		ctx,
+		[]byte(getAccountStorageScript),
+		[]cadence.Value{address.ToCadence()},
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	array, ok := value.(cadence.Array)
+	if !ok || len(array.Values) != 2 {
+		return 0, 0, errors.New(errorMessage("unexpected account storage script result %v", value))
+	}
+
+	usedValue, ok := array.Values[0].(cadence.UInt64)
+	if !ok {
+		return 0, 0, errors.New(errorMessage("unexpected used storage value %v", array.Values[0]))
+	}
+
+	capacityValue, ok := array.Values[1].(cadence.UInt64)
+	if !ok {
+		return 0, 0, errors.New(errorMessage("unexpected storage capacity value %v", array.Values[1]))
+	}
+
+	return uint64(usedValue), uint64(capacityValue), nil
+}
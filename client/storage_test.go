@@ -0,0 +1,83 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/portto/blocto-flow-go-sdk/client"
+	"github.com/portto/blocto-flow-go-sdk/test"
+)
+
+func TestClient_GetAccountStorage(t *testing.T) {
+	addresses := test.AddressGenerator()
+
+	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		address := addresses.New()
+
+		encodedValue, err := jsoncdc.Encode(cadence.NewArray([]cadence.Value{
+			cadence.NewUInt64(1024),
+			cadence.NewUInt64(100000),
+		}))
+		require.NoError(t, err)
+
+		encodedAddress, err := jsoncdc.Encode(address.ToCadence())
+		require.NoError(t, err)
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.MatchedBy(func(req *access.ExecuteScriptAtLatestBlockRequest) bool {
+			return len(req.Arguments) == 1 && string(req.Arguments[0]) == string(encodedAddress)
+		})).Return(&access.ExecuteScriptResponse{Value: encodedValue}, nil)
+
+		used, capacity, err := c.GetAccountStorage(ctx, address)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(1024), used)
+		assert.Equal(t, uint64(100000), capacity)
+	}))
+
+	t.Run("Unexpected result", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		encodedValue, err := jsoncdc.Encode(cadence.NewInt(42))
+		require.NoError(t, err)
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).
+			Return(&access.ExecuteScriptResponse{Value: encodedValue}, nil)
+
+		_, _, err = c.GetAccountStorage(ctx, addresses.New())
+		assert.Error(t, err)
+	}))
+
+	t.Run("Internal error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).
+			Return(nil, errInternal)
+
+		_, _, err := c.GetAccountStorage(ctx, addresses.New())
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	}))
+}
@@ -0,0 +1,203 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/portto/blocto-flow-go-sdk"
+)
+
+// SubscribeTransactionResults streams the transaction results of each sealed block,
+// starting at the given height.
+//
+// The results of each block are delivered on the first channel as a single slice, in
+// block height order. Transaction results are ordered as their transactions appear in
+// the block's collections.
+//
+// If the access node is temporarily unavailable, the subscription retries and resumes
+// from the first block that has not been delivered. Any other error is delivered on the
+// second channel and ends the subscription. Both channels are closed when the
+// subscription ends.
+//
+// The Access API implemented by this client does not support block streaming, so new
+// sealed blocks are discovered by polling.
+func (c *Client) SubscribeTransactionResults(
+	ctx context.Context,
+	startHeight uint64,
+	opts ...WaitOption,
+) (<-chan []*flow.TransactionResult, <-chan error) {
+	results := make(chan []*flow.TransactionResult)
+	errs := make(chan error, 1)
+
+	conf := newWaitConfig(opts)
+
+	go func() {
+		defer close(results)
+		defer close(errs)
+
+		height := startHeight
+
+		for {
+			err := c.emitSealedBlockResults(ctx, &height, results)
+			if err != nil && !isTransientError(err) {
+				errs <- err
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			case <-time.After(conf.pollInterval):
+			}
+		}
+	}()
+
+	return results, errs
+}
+
+// emitSealedBlockResults sends the transaction results of all sealed blocks from the given
+// height onwards, advancing the height after each block is delivered.
+func (c *Client) emitSealedBlockResults(
+	ctx context.Context,
+	height *uint64,
+	results chan<- []*flow.TransactionResult,
+) error {
+	sealedHeight, err := c.GetLatestBlockHeight(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	for ; *height <= sealedHeight; *height++ {
+		blockResults, err := c.getTransactionResultsByHeight(ctx, *height)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case results <- blockResults:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) getTransactionResultsByHeight(ctx context.Context, height uint64) ([]*flow.TransactionResult, error) {
+	block, err := c.GetBlockByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getBlockTransactionResults(ctx, block)
+}
+
+// SubscribeAccountChanges streams snapshots of an account whenever it changes.
+//
+// The current account is delivered on the first channel when the subscription starts,
+// followed by a new snapshot each time the balance, code or keys of the account differ
+// from the last delivered snapshot.
+//
+// If the access node is temporarily unavailable, the subscription retries. Any other error
+// is delivered on the second channel and ends the subscription. Both channels are closed
+// when the subscription ends.
+//
+// The Access API implemented by this client does not support account streaming, so
+// changes are discovered by polling the account at the latest sealed block.
+func (c *Client) SubscribeAccountChanges(
+	ctx context.Context,
+	address flow.Address,
+	opts ...WaitOption,
+) (<-chan *flow.Account, <-chan error) {
+	accounts := make(chan *flow.Account)
+	errs := make(chan error, 1)
+
+	conf := newWaitConfig(opts)
+
+	go func() {
+		defer close(accounts)
+		defer close(errs)
+
+		var last *flow.Account
+
+		for {
+			account, err := c.GetAccountAtLatestBlock(ctx, address)
+			if err != nil && !isTransientError(err) {
+				errs <- err
+				return
+			}
+
+			if err == nil && (last == nil || !accountsEqual(last, account)) {
+				select {
+				case accounts <- account:
+					last = account
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			case <-time.After(conf.pollInterval):
+			}
+		}
+	}()
+
+	return accounts, errs
+}
+
+// accountsEqual returns true if both accounts have the same balance, code and keys.
+func accountsEqual(a, b *flow.Account) bool {
+	if a.Address != b.Address || a.Balance != b.Balance || !bytes.Equal(a.Code, b.Code) {
+		return false
+	}
+
+	if len(a.Keys) != len(b.Keys) {
+		return false
+	}
+
+	for i := range a.Keys {
+		keyA, keyB := a.Keys[i], b.Keys[i]
+
+		if keyA.Index != keyB.Index ||
+			keyA.SequenceNumber != keyB.SequenceNumber ||
+			keyA.Revoked != keyB.Revoked ||
+			!bytes.Equal(keyA.Encode(), keyB.Encode()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isTransientError returns true if the error indicates that the access node is
+// temporarily unavailable and the request can be retried.
+func isTransientError(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
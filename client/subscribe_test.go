@@ -0,0 +1,185 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/portto/blocto-flow-go-sdk"
+	"github.com/portto/blocto-flow-go-sdk/client"
+	"github.com/portto/blocto-flow-go-sdk/client/convert"
+	"github.com/portto/blocto-flow-go-sdk/test"
+)
+
+func TestClient_SubscribeTransactionResults(t *testing.T) {
+	blocks := test.BlockGenerator()
+	collections := test.CollectionGenerator()
+
+	t.Run("Two consecutive blocks", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		blockA := blocks.New()
+		blockB := blocks.New()
+
+		latest, err := convert.BlockHeaderToMessage(blockB.BlockHeader)
+		require.NoError(t, err)
+
+		rpc.On("GetLatestBlockHeader", mock.Anything, mock.Anything).
+			Return(&access.BlockHeaderResponse{Block: latest}, nil)
+
+		for _, block := range []*flow.Block{blockA, blockB} {
+			msg, err := convert.BlockToMessage(*block)
+			require.NoError(t, err)
+
+			rpc.On("GetBlockByHeight", mock.Anything, &access.GetBlockByHeightRequest{Height: block.Height}).
+				Return(&access.BlockResponse{Block: msg}, nil)
+		}
+
+		collection := collections.New()
+
+		rpc.On("GetCollectionByID", mock.Anything, mock.Anything).
+			Return(&access.CollectionResponse{Collection: convert.CollectionToMessage(*collection)}, nil)
+
+		rpc.On("GetTransactionResult", mock.Anything, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusSealed), nil)
+
+		results, _ := c.SubscribeTransactionResults(
+			ctx,
+			blockA.Height,
+			client.WithPollInterval(testPollInterval),
+		)
+
+		expectedCount := len(blockA.CollectionGuarantees()) * len(collection.TransactionIDs)
+
+		for i := 0; i < 2; i++ {
+			blockResults := <-results
+			assert.Len(t, blockResults, expectedCount)
+		}
+
+		rpc.AssertCalled(t, "GetBlockByHeight", mock.Anything, &access.GetBlockByHeightRequest{Height: blockA.Height})
+		rpc.AssertCalled(t, "GetBlockByHeight", mock.Anything, &access.GetBlockByHeightRequest{Height: blockB.Height})
+	}))
+
+	t.Run("Resume after unavailable", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		block := blocks.New()
+
+		latest, err := convert.BlockHeaderToMessage(block.BlockHeader)
+		require.NoError(t, err)
+
+		msg, err := convert.BlockToMessage(*block)
+		require.NoError(t, err)
+
+		rpc.On("GetLatestBlockHeader", mock.Anything, mock.Anything).
+			Return(&access.BlockHeaderResponse{Block: latest}, nil)
+
+		rpc.On("GetBlockByHeight", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.Unavailable, "unavailable")).
+			Once()
+
+		rpc.On("GetBlockByHeight", mock.Anything, mock.Anything).
+			Return(&access.BlockResponse{Block: msg}, nil)
+
+		rpc.On("GetCollectionByID", mock.Anything, mock.Anything).
+			Return(&access.CollectionResponse{Collection: convert.CollectionToMessage(*collections.New())}, nil)
+
+		rpc.On("GetTransactionResult", mock.Anything, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusSealed), nil)
+
+		results, _ := c.SubscribeTransactionResults(
+			ctx,
+			block.Height,
+			client.WithPollInterval(testPollInterval),
+		)
+
+		blockResults := <-results
+		assert.NotEmpty(t, blockResults)
+	}))
+
+	t.Run("Internal error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetLatestBlockHeader", ctx, mock.Anything).
+			Return(nil, errInternal)
+
+		results, errs := c.SubscribeTransactionResults(ctx, 1)
+
+		_, ok := <-results
+		assert.False(t, ok)
+
+		err := <-errs
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	}))
+}
+
+func TestClient_SubscribeAccountChanges(t *testing.T) {
+	accounts := test.AccountGenerator()
+
+	t.Run("Balance update", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		account := accounts.New()
+
+		updated := *account
+		updated.Balance = account.Balance + 100
+
+		rpc.On("GetAccountAtLatestBlock", mock.Anything, mock.Anything).
+			Return(&access.AccountResponse{Account: convert.AccountToMessage(*account)}, nil).
+			Times(3)
+
+		rpc.On("GetAccountAtLatestBlock", mock.Anything, mock.Anything).
+			Return(&access.AccountResponse{Account: convert.AccountToMessage(updated)}, nil)
+
+		changes, _ := c.SubscribeAccountChanges(
+			ctx,
+			account.Address,
+			client.WithPollInterval(testPollInterval),
+		)
+
+		first := <-changes
+		assert.Equal(t, account.Balance, first.Balance)
+
+		second := <-changes
+		assert.Equal(t, updated.Balance, second.Balance)
+	}))
+
+	t.Run("Error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetAccountAtLatestBlock", mock.Anything, mock.Anything).
+			Return(nil, errNotFound)
+
+		changes, errs := c.SubscribeAccountChanges(ctx, accounts.New().Address)
+
+		_, ok := <-changes
+		assert.False(t, ok)
+
+		err := <-errs
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	}))
+}
@@ -0,0 +1,289 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/portto/blocto-flow-go-sdk"
+)
+
+// DefaultPollInterval is the default interval between transaction result requests
+// when waiting for a transaction status.
+const DefaultPollInterval = time.Second
+
+// A WaitOption configures how the client polls for a transaction result.
+type WaitOption func(*waitConfig)
+
+type waitConfig struct {
+	pollInterval    time.Duration
+	maxPollInterval time.Duration
+	progress        func(elapsed, estimate time.Duration)
+}
+
+func newWaitConfig(opts []WaitOption) waitConfig {
+	conf := waitConfig{
+		pollInterval: DefaultPollInterval,
+	}
+
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	return conf
+}
+
+// WithPollInterval sets the interval between transaction result requests.
+func WithPollInterval(interval time.Duration) WaitOption {
+	return func(c *waitConfig) {
+		c.pollInterval = interval
+	}
+}
+
+// WithBackoff enables exponential backoff between transaction result requests.
+//
+// The first interval is initial, and each following interval is doubled up to max.
+func WithBackoff(initial, max time.Duration) WaitOption {
+	return func(c *waitConfig) {
+		c.pollInterval = initial
+		c.maxPollInterval = max
+	}
+}
+
+// nextPollInterval returns the interval to wait after the given interval.
+func (c waitConfig) nextPollInterval(interval time.Duration) time.Duration {
+	if c.maxPollInterval <= interval {
+		return interval
+	}
+
+	interval *= 2
+	if interval > c.maxPollInterval {
+		return c.maxPollInterval
+	}
+
+	return interval
+}
+
+// WithSealProgress sets a callback that WaitForSeal calls after each transaction result
+// request with the time elapsed since waiting started and the estimated total time to seal,
+// as returned by EstimateTimeToSeal.
+//
+// The estimate is zero if it cannot be computed.
+func WithSealProgress(f func(elapsed, estimate time.Duration)) WaitOption {
+	return func(c *waitConfig) {
+		c.progress = f
+	}
+}
+
+// EstimateTimeToSeal estimates how long a transaction submitted now takes to be sealed.
+//
+// The average block time is estimated from the timestamps of recent finalized blocks. The
+// estimate is the time needed to produce the blocks that are finalized but not yet
+// sealed, plus one block for the transaction to be included.
+func (c *Client) EstimateTimeToSeal(ctx context.Context) (time.Duration, error) {
+	finalized, err := c.GetLatestBlockHeader(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+
+	sealed, err := c.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+
+	blockTime, err := c.averageBlockTime(ctx, finalized)
+	if err != nil {
+		return 0, err
+	}
+
+	unsealed := uint64(0)
+	if finalized.Height > sealed.Height {
+		unsealed = finalized.Height - sealed.Height
+	}
+
+	return blockTime * time.Duration(unsealed+1), nil
+}
+
+// pollTransactionResult repeatedly fetches the result of a transaction and passes it to
+// the given callback until the callback returns true, an RPC fails or the context is done.
+func (c *Client) pollTransactionResult(
+	ctx context.Context,
+	txID flow.Identifier,
+	conf waitConfig,
+	f func(result *flow.TransactionResult) bool,
+) error {
+	interval := conf.pollInterval
+
+	for {
+		result, err := c.GetTransactionResult(ctx, txID)
+		if err != nil {
+			return err
+		}
+
+		if f(result) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval = conf.nextPollInterval(interval)
+	}
+}
+
+// SendAndSubscribeTransactionStatuses submits a transaction to the network and streams
+// its status transitions until it is sealed or expired.
+//
+// A result is delivered on the first channel each time the transaction status changes.
+// If the transaction cannot be submitted, its result cannot be fetched or the context is
+// cancelled before the transaction reaches a final status, the error is delivered on the
+// second channel. Both channels are closed when the subscription ends.
+//
+// The Access API implemented by this client does not support status streaming, so
+// status updates are retrieved by polling.
+func (c *Client) SendAndSubscribeTransactionStatuses(
+	ctx context.Context,
+	tx flow.Transaction,
+	opts ...WaitOption,
+) (<-chan flow.TransactionResult, <-chan error) {
+	results := make(chan flow.TransactionResult)
+	errs := make(chan error, 1)
+
+	conf := newWaitConfig(opts)
+
+	go func() {
+		defer close(results)
+		defer close(errs)
+
+		err := c.SendTransaction(ctx, tx)
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		lastStatus := flow.TransactionStatusUnknown
+		cancelled := false
+
+		err = c.pollTransactionResult(ctx, tx.ID(), conf, func(result *flow.TransactionResult) bool {
+			if result.Status == lastStatus {
+				return false
+			}
+
+			lastStatus = result.Status
+
+			select {
+			case results <- *result:
+			case <-ctx.Done():
+				cancelled = true
+				return true
+			}
+
+			return result.Status.IsFinal()
+		})
+		if err == nil && cancelled {
+			err = ctx.Err()
+		}
+
+		if err != nil {
+			errs <- err
+		}
+	}()
+
+	return results, errs
+}
+
+// WaitForSeal blocks until a transaction is sealed or expired and returns its final result.
+//
+// This function returns an error if the result cannot be fetched or the context is
+// cancelled before the transaction reaches a final status.
+func (c *Client) WaitForSeal(
+	ctx context.Context,
+	txID flow.Identifier,
+	opts ...WaitOption,
+) (*flow.TransactionResult, error) {
+	conf := newWaitConfig(opts)
+
+	var estimate time.Duration
+	if conf.progress != nil {
+		// progress is reported without an estimate if it cannot be computed
+		estimate, _ = c.EstimateTimeToSeal(ctx)
+	}
+
+	start := time.Now()
+
+	var final *flow.TransactionResult
+
+	err := c.pollTransactionResult(ctx, txID, conf, func(result *flow.TransactionResult) bool {
+		final = result
+
+		if conf.progress != nil {
+			conf.progress(time.Since(start), estimate)
+		}
+
+		return result.Status.IsFinal()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return final, nil
+}
+
+// WaitForStatus blocks until a transaction reaches or passes the target status and returns
+// its result, polling at the given interval.
+//
+// This allows callers to stop waiting as soon as a transaction is, for example, executed
+// rather than sealed.
+//
+// This function returns an error if the transaction expires before reaching the target
+// status, if the result cannot be fetched, or if the context is cancelled.
+func (c *Client) WaitForStatus(
+	ctx context.Context,
+	txID flow.Identifier,
+	target flow.TransactionStatus,
+	pollInterval time.Duration,
+) (*flow.TransactionResult, error) {
+	conf := newWaitConfig([]WaitOption{WithPollInterval(pollInterval)})
+
+	var final *flow.TransactionResult
+
+	err := c.pollTransactionResult(ctx, txID, conf, func(result *flow.TransactionResult) bool {
+		final = result
+
+		if result.Status == flow.TransactionStatusExpired {
+			return true
+		}
+
+		return result.Status >= target
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if final.Status == flow.TransactionStatusExpired && target != flow.TransactionStatusExpired {
+		return nil, errors.New(errorMessage("transaction %s expired before reaching status %s", txID, target))
+	}
+
+	return final, nil
+}
@@ -0,0 +1,331 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/portto/blocto-flow-go-sdk"
+	"github.com/portto/blocto-flow-go-sdk/client"
+	"github.com/portto/blocto-flow-go-sdk/test"
+)
+
+const testPollInterval = time.Millisecond
+
+func transactionResultResponse(status flow.TransactionStatus) *access.TransactionResultResponse {
+	return &access.TransactionResultResponse{
+		Status: entities.TransactionStatus(status),
+	}
+}
+
+func TestClient_SendAndSubscribeTransactionStatuses(t *testing.T) {
+	transactions := test.TransactionGenerator()
+
+	t.Run("Pending to sealed", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		tx := transactions.New()
+
+		rpc.On("SendTransaction", ctx, mock.Anything).
+			Return(&access.SendTransactionResponse{Id: tx.ID().Bytes()}, nil)
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusPending), nil).
+			Twice()
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusSealed), nil).
+			Once()
+
+		results, errs := c.SendAndSubscribeTransactionStatuses(
+			ctx,
+			*tx,
+			client.WithPollInterval(testPollInterval),
+		)
+
+		statuses := make([]flow.TransactionStatus, 0)
+		for result := range results {
+			statuses = append(statuses, result.Status)
+		}
+
+		assert.NoError(t, <-errs)
+		assert.Equal(t, []flow.TransactionStatus{
+			flow.TransactionStatusPending,
+			flow.TransactionStatusSealed,
+		}, statuses)
+	}))
+
+	t.Run("Cancelled before final status", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		tx := transactions.New()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		executedFetched := make(chan struct{})
+
+		rpc.On("SendTransaction", mock.Anything, mock.Anything).
+			Return(&access.SendTransactionResponse{Id: tx.ID().Bytes()}, nil)
+
+		rpc.On("GetTransactionResult", mock.Anything, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusPending), nil).
+			Once()
+
+		rpc.On("GetTransactionResult", mock.Anything, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusExecuted), nil).
+			Run(func(mock.Arguments) {
+				select {
+				case <-executedFetched:
+				default:
+					close(executedFetched)
+				}
+			})
+
+		results, errs := c.SendAndSubscribeTransactionStatuses(
+			ctx,
+			*tx,
+			client.WithPollInterval(testPollInterval),
+		)
+
+		result := <-results
+		assert.Equal(t, flow.TransactionStatusPending, result.Status)
+
+		// cancel while the executed result is waiting to be delivered
+		<-executedFetched
+		cancel()
+
+		assert.Equal(t, context.Canceled, <-errs)
+
+		for range results {
+		}
+	}))
+
+	t.Run("Send error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		tx := transactions.New()
+
+		rpc.On("SendTransaction", ctx, mock.Anything).
+			Return(nil, errInternal)
+
+		results, errs := c.SendAndSubscribeTransactionStatuses(ctx, *tx)
+
+		_, ok := <-results
+		assert.False(t, ok)
+
+		err := <-errs
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	}))
+}
+
+func TestClient_WaitForSeal(t *testing.T) {
+	ids := test.IdentifierGenerator()
+
+	t.Run("Pending to sealed", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusPending), nil).
+			Twice()
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusSealed), nil).
+			Once()
+
+		result, err := c.WaitForSeal(ctx, ids.New(), client.WithPollInterval(testPollInterval))
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+		rpc.AssertNumberOfCalls(t, "GetTransactionResult", 3)
+	}))
+
+	t.Run("Error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(nil, errNotFound)
+
+		result, err := c.WaitForSeal(ctx, ids.New())
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Nil(t, result)
+	}))
+}
+
+// mockRecentBlocks mocks a chain whose latest finalized block is at height 100, produced
+// every two seconds, with the latest sealed block at height 97.
+func mockRecentBlocks(t *testing.T, ctx context.Context, rpc *MockRPCClient) {
+	mockRecentBlocksWithDuration(t, ctx, rpc, 200*time.Second)
+}
+
+// mockRecentBlocksWithDuration mocks 100 finalized blocks produced over the given duration,
+// of which the last three are not yet sealed.
+func mockRecentBlocksWithDuration(t *testing.T, ctx context.Context, rpc *MockRPCClient, d time.Duration) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rpc.On("GetLatestBlockHeader", ctx, &access.GetLatestBlockHeaderRequest{IsSealed: false}).
+		Return(blockHeaderResponse(t, flow.BlockHeader{Height: 100, Timestamp: start.Add(d)}), nil)
+
+	rpc.On("GetLatestBlockHeader", ctx, &access.GetLatestBlockHeaderRequest{IsSealed: true}).
+		Return(blockHeaderResponse(t, flow.BlockHeader{Height: 97, Timestamp: start.Add(d * 97 / 100)}), nil)
+
+	rpc.On("GetBlockHeaderByHeight", ctx, &access.GetBlockHeaderByHeightRequest{Height: 0}).
+		Return(blockHeaderResponse(t, flow.BlockHeader{Height: 0, Timestamp: start}), nil)
+}
+
+func TestClient_EstimateTimeToSeal(t *testing.T) {
+	t.Run("Average block time", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		mockRecentBlocks(t, ctx, rpc)
+
+		estimate, err := c.EstimateTimeToSeal(ctx)
+		require.NoError(t, err)
+
+		// three unsealed blocks plus one block for inclusion, at two seconds per block
+		assert.Equal(t, 8*time.Second, estimate)
+	}))
+
+	t.Run("Identical timestamps", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		mockRecentBlocksWithDuration(t, ctx, rpc, 0)
+
+		_, err := c.EstimateTimeToSeal(ctx)
+		assert.Error(t, err)
+	}))
+
+	t.Run("Error", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetLatestBlockHeader", ctx, mock.Anything).
+			Return(nil, errInternal)
+
+		_, err := c.EstimateTimeToSeal(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	}))
+}
+
+func TestClient_WaitForSeal_Progress(t *testing.T) {
+	ids := test.IdentifierGenerator()
+
+	t.Run("Reports estimate", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		mockRecentBlocks(t, ctx, rpc)
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusPending), nil).
+			Once()
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusSealed), nil).
+			Once()
+
+		estimates := make([]time.Duration, 0)
+
+		_, err := c.WaitForSeal(
+			ctx,
+			ids.New(),
+			client.WithPollInterval(testPollInterval),
+			client.WithSealProgress(func(elapsed, estimate time.Duration) {
+				estimates = append(estimates, estimate)
+			}),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []time.Duration{8 * time.Second, 8 * time.Second}, estimates)
+	}))
+}
+
+func TestClient_WaitForSeal_Backoff(t *testing.T) {
+	ids := test.IdentifierGenerator()
+
+	t.Run("Pending to executed to sealed", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusPending), nil).
+			Once()
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusExecuted), nil).
+			Twice()
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusSealed), nil).
+			Once()
+
+		start := time.Now()
+
+		result, err := c.WaitForSeal(ctx, ids.New(), client.WithBackoff(time.Millisecond, 4*time.Millisecond))
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+		rpc.AssertNumberOfCalls(t, "GetTransactionResult", 4)
+
+		// intervals of 1, 2 and 4 milliseconds
+		assert.True(t, time.Since(start) >= 7*time.Millisecond)
+	}))
+
+	t.Run("Expired", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusExpired), nil)
+
+		result, err := c.WaitForSeal(ctx, ids.New(), client.WithBackoff(time.Hour, time.Hour))
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.TransactionStatusExpired, result.Status)
+		rpc.AssertNumberOfCalls(t, "GetTransactionResult", 1)
+	}))
+}
+
+func TestClient_WaitForStatus(t *testing.T) {
+	ids := test.IdentifierGenerator()
+
+	t.Run("Stop at executed", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusFinalized), nil).
+			Once()
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusExecuted), nil).
+			Once()
+
+		result, err := c.WaitForStatus(ctx, ids.New(), flow.TransactionStatusExecuted, testPollInterval)
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.TransactionStatusExecuted, result.Status)
+		rpc.AssertNumberOfCalls(t, "GetTransactionResult", 2)
+	}))
+
+	t.Run("Passed target", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusSealed), nil).
+			Once()
+
+		result, err := c.WaitForStatus(ctx, ids.New(), flow.TransactionStatusExecuted, testPollInterval)
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+	}))
+
+	t.Run("Expired", clientTest(func(t *testing.T, ctx context.Context, rpc *MockRPCClient, c *client.Client) {
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(flow.TransactionStatusExpired), nil).
+			Once()
+
+		result, err := c.WaitForStatus(ctx, ids.New(), flow.TransactionStatusExecuted, testPollInterval)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	}))
+}
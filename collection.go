@@ -46,4 +46,11 @@ func (c Collection) Encode() []byte {
 // A CollectionGuarantee is an attestation signed by the nodes that have guaranteed a collection.
 type CollectionGuarantee struct {
 	CollectionID Identifier
+	// SignerIDs are the IDs of the collection nodes that signed the guarantee.
+	//
+	// The Access API does not currently expose signer IDs, so this field is empty
+	// for guarantees fetched from an access node.
+	SignerIDs []Identifier
+	// Signatures are the signatures of the collection nodes that guaranteed the collection.
+	Signatures [][]byte
 }
@@ -0,0 +1,61 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+// knownContracts maps each network to the addresses of its well-known contracts.
+var knownContracts = map[ChainID]map[string]Address{
+	Mainnet: {
+		"FlowServiceAccount": HexToAddress("e467b9dd11fa00df"),
+		"FungibleToken":      HexToAddress("f233dcee88fe0abe"),
+		"FlowToken":          HexToAddress("1654653399040a61"),
+		"FlowFees":           HexToAddress("f919ee77447b7497"),
+		"NonFungibleToken":   HexToAddress("1d7e57aa55817448"),
+	},
+	Testnet: {
+		"FlowServiceAccount": HexToAddress("8c5303eaa26202d6"),
+		"FungibleToken":      HexToAddress("9a0766d93b6608b7"),
+		"FlowToken":          HexToAddress("7e60df042a9c0868"),
+		"FlowFees":           HexToAddress("912d5440f7e3769e"),
+		"NonFungibleToken":   HexToAddress("631e88ae7f1d7c20"),
+	},
+	Emulator: {
+		"FlowServiceAccount": HexToAddress("f8d6e0586b0a20c7"),
+		"FungibleToken":      HexToAddress("ee82856bf20e2aa6"),
+		"FlowToken":          HexToAddress("0ae53cb6e3f42a79"),
+		"FlowFees":           HexToAddress("e5a8b7f23e8b548f"),
+	},
+}
+
+// KnownContractAddresses returns the addresses of the well-known contracts deployed on
+// the given network, keyed by contract name.
+//
+// This function returns false if the network is not known.
+func KnownContractAddresses(chain ChainID) (map[string]Address, bool) {
+	contracts, ok := knownContracts[chain]
+	if !ok {
+		return nil, false
+	}
+
+	addresses := make(map[string]Address, len(contracts))
+	for name, address := range contracts {
+		addresses[name] = address
+	}
+
+	return addresses, true
+}
@@ -0,0 +1,220 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crypto
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/portto/blocto-flow-go-sdk/crypto/internal/crypto"
+	"github.com/portto/blocto-flow-go-sdk/crypto/internal/crypto/hash"
+)
+
+// A PrivateKeyImplementation is the algorithm-specific implementation of a private key.
+type PrivateKeyImplementation interface {
+	// Sign signs the given message with the provided hasher.
+	Sign(message []byte, hasher Hasher) ([]byte, error)
+	// PublicKey returns the public key for this private key.
+	PublicKey() PublicKeyImplementation
+	// Encode returns the raw byte encoding of this private key.
+	Encode() []byte
+}
+
+// A PublicKeyImplementation is the algorithm-specific implementation of a public key.
+type PublicKeyImplementation interface {
+	// Verify verifies the given signature against a message with the provided hasher.
+	Verify(sig, message []byte, hasher Hasher) (bool, error)
+	// Encode returns the raw byte encoding of this public key.
+	Encode() []byte
+}
+
+// A SignatureAlgorithmFactory creates keys for a signature algorithm.
+type SignatureAlgorithmFactory interface {
+	// Name returns the string representation of the signature algorithm.
+	Name() string
+	// CompatibleHashAlgorithm returns true if the hash algorithm can be used with the signature algorithm.
+	CompatibleHashAlgorithm(hashAlgo HashAlgorithm) bool
+	// GeneratePrivateKey generates a private key from a seed of at least MinSeedLength bytes.
+	GeneratePrivateKey(seed []byte) (PrivateKeyImplementation, error)
+	// DecodePrivateKey decodes a raw byte encoded private key.
+	DecodePrivateKey(b []byte) (PrivateKeyImplementation, error)
+	// DecodePublicKey decodes a raw byte encoded public key.
+	DecodePublicKey(b []byte) (PublicKeyImplementation, error)
+}
+
+var signatureAlgorithms = struct {
+	sync.RWMutex
+	names     map[SignatureAlgorithm]string
+	factories map[SignatureAlgorithm]SignatureAlgorithmFactory
+}{
+	names: map[SignatureAlgorithm]string{
+		UnknownSignatureAlgorithm: "UNKNOWN",
+		BLS_BLS12381:              "BLS_BLS12381",
+	},
+	factories: make(map[SignatureAlgorithm]SignatureAlgorithmFactory),
+}
+
+func init() {
+	_ = RegisterSignatureAlgorithm(ECDSA_P256, ecdsaFactory{
+		algo:    ECDSA_P256,
+		seedLen: crypto.KeyGenSeedMinLenECDSAP256,
+	})
+	_ = RegisterSignatureAlgorithm(ECDSA_secp256k1, ecdsaFactory{
+		algo:    ECDSA_secp256k1,
+		seedLen: crypto.KeyGenSeedMinLenECDSASecp256k1,
+	})
+}
+
+// RegisterSignatureAlgorithm registers the factory used to generate and decode keys
+// for a signature algorithm.
+//
+// Once registered, the algorithm is supported by GeneratePrivateKey, DecodePrivateKey,
+// DecodePublicKey, CompatibleAlgorithms and the string conversion functions.
+//
+// This function returns an error if the algorithm is unknown or already registered.
+func RegisterSignatureAlgorithm(algo SignatureAlgorithm, factory SignatureAlgorithmFactory) error {
+	if algo == UnknownSignatureAlgorithm {
+		return fmt.Errorf("crypto: cannot register %s signature algorithm", algo)
+	}
+
+	signatureAlgorithms.Lock()
+	defer signatureAlgorithms.Unlock()
+
+	if _, ok := signatureAlgorithms.factories[algo]; ok {
+		return fmt.Errorf("crypto: signature algorithm %s is already registered", algo.name())
+	}
+
+	signatureAlgorithms.names[algo] = factory.Name()
+	signatureAlgorithms.factories[algo] = factory
+
+	return nil
+}
+
+func signatureAlgorithmFactory(algo SignatureAlgorithm) (SignatureAlgorithmFactory, bool) {
+	signatureAlgorithms.RLock()
+	defer signatureAlgorithms.RUnlock()
+
+	factory, ok := signatureAlgorithms.factories[algo]
+	return factory, ok
+}
+
+// name returns the registered name of the algorithm, which must be called with the registry locked.
+func (f SignatureAlgorithm) name() string {
+	if name, ok := signatureAlgorithms.names[f]; ok {
+		return name
+	}
+
+	return signatureAlgorithms.names[UnknownSignatureAlgorithm]
+}
+
+// ecdsaFactory creates keys using the ECDSA implementation of the internal crypto package.
+type ecdsaFactory struct {
+	algo    SignatureAlgorithm
+	seedLen int
+}
+
+func (f ecdsaFactory) Name() string {
+	return crypto.SigningAlgorithm(f.algo).String()
+}
+
+func (f ecdsaFactory) CompatibleHashAlgorithm(hashAlgo HashAlgorithm) bool {
+	return hashAlgo == SHA2_256 || hashAlgo == SHA3_256
+}
+
+func (f ecdsaFactory) GeneratePrivateKey(seed []byte) (PrivateKeyImplementation, error) {
+	// expand the seed and uniformize its entropy
+	generationTag := keyGenerationKMACTag(f.algo)
+	customizer := []byte("")
+	hasher, err := hash.NewKMAC_128(generationTag, customizer, f.seedLen)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedSeed := hasher.ComputeHash(seed)
+
+	privKey, err := crypto.GeneratePrivateKey(crypto.SigningAlgorithm(f.algo), hashedSeed)
+	if err != nil {
+		return nil, err
+	}
+
+	return internalPrivateKey{privKey}, nil
+}
+
+func (f ecdsaFactory) DecodePrivateKey(b []byte) (PrivateKeyImplementation, error) {
+	privKey, err := crypto.DecodePrivateKey(crypto.SigningAlgorithm(f.algo), b)
+	if err != nil {
+		return nil, err
+	}
+
+	return internalPrivateKey{privKey}, nil
+}
+
+func (f ecdsaFactory) DecodePublicKey(b []byte) (PublicKeyImplementation, error) {
+	pubKey, err := crypto.DecodePublicKey(crypto.SigningAlgorithm(f.algo), b)
+	if err != nil {
+		return nil, err
+	}
+
+	return internalPublicKey{pubKey}, nil
+}
+
+type internalPrivateKey struct {
+	privateKey crypto.PrivateKey
+}
+
+func (sk internalPrivateKey) Sign(message []byte, hasher Hasher) ([]byte, error) {
+	return sk.privateKey.Sign(message, hasher)
+}
+
+func (sk internalPrivateKey) PublicKey() PublicKeyImplementation {
+	return internalPublicKey{sk.privateKey.PublicKey()}
+}
+
+func (sk internalPrivateKey) Encode() []byte {
+	return sk.privateKey.Encode()
+}
+
+// deterministicPrivateKey is implemented by private keys that support signing
+// with a nonce derived from the key and the message.
+type deterministicPrivateKey interface {
+	SignDeterministic(message []byte, hasher Hasher) ([]byte, error)
+}
+
+func (sk internalPrivateKey) SignDeterministic(message []byte, hasher Hasher) ([]byte, error) {
+	dsk, ok := sk.privateKey.(interface {
+		SignDeterministic([]byte, hash.Hasher) (crypto.Signature, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("crypto: deterministic signing is not supported for %s algorithm", sk.privateKey.Algorithm())
+	}
+
+	return dsk.SignDeterministic(message, hasher)
+}
+
+type internalPublicKey struct {
+	publicKey crypto.PublicKey
+}
+
+func (pk internalPublicKey) Verify(sig, message []byte, hasher Hasher) (bool, error) {
+	return pk.publicKey.Verify(sig, message, hasher)
+}
+
+func (pk internalPublicKey) Encode() []byte {
+	return pk.publicKey.Encode()
+}
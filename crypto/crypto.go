@@ -26,7 +26,6 @@ import (
 	"fmt"
 
 	"github.com/portto/blocto-flow-go-sdk/crypto/internal/crypto"
-	"github.com/portto/blocto-flow-go-sdk/crypto/internal/crypto/hash"
 )
 
 // SignatureAlgorithm is an identifier for a signature algorithm (and parameters if applicable).
@@ -44,21 +43,24 @@ const (
 
 // String returns the string representation of this signature algorithm.
 func (f SignatureAlgorithm) String() string {
-	return [...]string{"UNKNOWN", "BLS_BLS12381", "ECDSA_P256", "ECDSA_secp256k1"}[f]
+	signatureAlgorithms.RLock()
+	defer signatureAlgorithms.RUnlock()
+
+	return f.name()
 }
 
 // StringToSignatureAlgorithm converts a string to a SignatureAlgorithm.
 func StringToSignatureAlgorithm(s string) SignatureAlgorithm {
-	switch s {
-	case BLS_BLS12381.String():
-		return BLS_BLS12381
-	case ECDSA_P256.String():
-		return ECDSA_P256
-	case ECDSA_secp256k1.String():
-		return ECDSA_secp256k1
-	default:
-		return UnknownSignatureAlgorithm
+	signatureAlgorithms.RLock()
+	defer signatureAlgorithms.RUnlock()
+
+	for algo, name := range signatureAlgorithms.names {
+		if name == s {
+			return algo
+		}
 	}
+
+	return UnknownSignatureAlgorithm
 }
 
 // HashAlgorithm is an identifier for a hash algorithm.
@@ -95,23 +97,18 @@ func StringToHashAlgorithm(s string) HashAlgorithm {
 
 // CompatibleAlgorithms returns true if the signature and hash algorithms are compatible.
 func CompatibleAlgorithms(sigAlgo SignatureAlgorithm, hashAlgo HashAlgorithm) bool {
-	switch sigAlgo {
-	case ECDSA_P256:
-		fallthrough
-	case ECDSA_secp256k1:
-		switch hashAlgo {
-		case SHA2_256:
-			fallthrough
-		case SHA3_256:
-			return true
-		}
+	factory, ok := signatureAlgorithmFactory(sigAlgo)
+	if !ok {
+		return false
 	}
-	return false
+
+	return factory.CompatibleHashAlgorithm(hashAlgo)
 }
 
 // A PrivateKey is a cryptographic private key that can be used for in-memory signing.
 type PrivateKey struct {
-	privateKey crypto.PrivateKey
+	algo       SignatureAlgorithm
+	privateKey PrivateKeyImplementation
 }
 
 // Sign signs the given message with this private key and the provided hasher.
@@ -123,12 +120,15 @@ func (sk PrivateKey) Sign(message []byte, hasher Hasher) ([]byte, error) {
 
 // Algorithm returns the signature algorithm for this private key.
 func (sk PrivateKey) Algorithm() SignatureAlgorithm {
-	return SignatureAlgorithm(sk.privateKey.Algorithm())
+	return sk.algo
 }
 
 // PublicKey returns the public key for this private key.
 func (sk PrivateKey) PublicKey() PublicKey {
-	return PublicKey{publicKey: sk.privateKey.PublicKey()}
+	return PublicKey{
+		algo:      sk.algo,
+		publicKey: sk.privateKey.PublicKey(),
+	}
 }
 
 // Encode returns the raw byte encoding of this private key.
@@ -138,7 +138,8 @@ func (sk PrivateKey) Encode() []byte {
 
 // A PublicKey is a cryptographic public key that can be used to verify signatures.
 type PublicKey struct {
-	publicKey crypto.PublicKey
+	algo      SignatureAlgorithm
+	publicKey PublicKeyImplementation
 }
 
 // Verify verifies the given signature against a message with this public key and the provided hasher.
@@ -151,7 +152,7 @@ func (pk PublicKey) Verify(sig, message []byte, hasher Hasher) (bool, error) {
 
 // Algorithm returns the signature algorithm for this public key.
 func (pk PublicKey) Algorithm() SignatureAlgorithm {
-	return SignatureAlgorithm(pk.publicKey.Algorithm())
+	return pk.algo
 }
 
 // Encode returns the raw byte encoding of this public key.
@@ -197,6 +198,69 @@ func NewNaiveSigner(privateKey PrivateKey, hashAlgo HashAlgorithm) NaiveSigner {
 	return NewInMemorySigner(privateKey, hashAlgo)
 }
 
+// A DeterministicSigner is a signer that always produces the same signature for the same
+// message.
+//
+// DeterministicSigner is intended for testing only. Its private key is derived from a fixed
+// seed and its signing nonces are derived from the key and the message, so signatures
+// are byte-stable across runs. The nonce derivation does not follow RFC 6979 and must not
+// be used to sign real transactions.
+type DeterministicSigner struct {
+	PrivateKey PrivateKey
+	Hasher     Hasher
+}
+
+// NewDeterministicSigner initializes and returns a new deterministic signer with a private key
+// derived from the given seed.
+//
+// The seed must be at least MinSeedLength bytes long. This function returns an error if the
+// signature algorithm does not support deterministic signing.
+func NewDeterministicSigner(
+	seed []byte,
+	sigAlgo SignatureAlgorithm,
+	hashAlgo HashAlgorithm,
+) (DeterministicSigner, error) {
+	privateKey, err := GeneratePrivateKey(sigAlgo, seed)
+	if err != nil {
+		return DeterministicSigner{}, err
+	}
+
+	if _, ok := privateKey.privateKey.(deterministicPrivateKey); !ok {
+		return DeterministicSigner{}, fmt.Errorf(
+			"crypto: deterministic signing is not supported for %s algorithm",
+			sigAlgo,
+		)
+	}
+
+	hasher, err := NewHasher(hashAlgo)
+	if err != nil {
+		return DeterministicSigner{}, err
+	}
+
+	return DeterministicSigner{
+		PrivateKey: privateKey,
+		Hasher:     hasher,
+	}, nil
+}
+
+// Sign signs the given message with the deterministic signer.
+func (s DeterministicSigner) Sign(message []byte) ([]byte, error) {
+	sk, ok := s.PrivateKey.privateKey.(deterministicPrivateKey)
+	if !ok {
+		return nil, fmt.Errorf(
+			"crypto: deterministic signing is not supported for %s algorithm",
+			s.PrivateKey.Algorithm(),
+		)
+	}
+
+	return sk.SignDeterministic(message, s.Hasher)
+}
+
+// PublicKey returns the public key of the deterministic signer.
+func (s DeterministicSigner) PublicKey() PublicKey {
+	return s.PrivateKey.PublicKey()
+}
+
 // MinSeedLength is the generic minimum seed length required to guarantee sufficient
 // entropy when generating keys.
 //
@@ -220,48 +284,40 @@ func GeneratePrivateKey(sigAlgo SignatureAlgorithm, seed []byte) (PrivateKey, er
 		)
 	}
 
-	// expand the seed and uniformize its entropy
-	var seedLen int
-	switch sigAlgo {
-	case ECDSA_P256:
-		seedLen = crypto.KeyGenSeedMinLenECDSAP256
-	case ECDSA_secp256k1:
-		seedLen = crypto.KeyGenSeedMinLenECDSASecp256k1
-	default:
+	factory, ok := signatureAlgorithmFactory(sigAlgo)
+	if !ok {
 		return PrivateKey{}, fmt.Errorf(
 			"crypto: Go SDK does not support key generation for %s algorithm",
 			sigAlgo,
 		)
 	}
 
-	generationTag := keyGenerationKMACTag(sigAlgo)
-	customizer := []byte("")
-	hasher, err := hash.NewKMAC_128(generationTag, customizer, seedLen)
-	if err != nil {
-		return PrivateKey{}, err
-	}
-
-	hashedSeed := hasher.ComputeHash(seed)
-
 	// generate the key
-	privKey, err := crypto.GeneratePrivateKey(crypto.SigningAlgorithm(sigAlgo), hashedSeed)
+	privKey, err := factory.GeneratePrivateKey(seed)
 	if err != nil {
 		return PrivateKey{}, err
 	}
 
 	return PrivateKey{
+		algo:       sigAlgo,
 		privateKey: privKey,
 	}, nil
 }
 
 // DecodePrivateKey decodes a raw byte encoded private key with the given signature algorithm.
 func DecodePrivateKey(sigAlgo SignatureAlgorithm, b []byte) (PrivateKey, error) {
-	privKey, err := crypto.DecodePrivateKey(crypto.SigningAlgorithm(sigAlgo), b)
+	factory, ok := signatureAlgorithmFactory(sigAlgo)
+	if !ok {
+		return PrivateKey{}, fmt.Errorf("crypto: Go SDK does not support %s algorithm", sigAlgo)
+	}
+
+	privKey, err := factory.DecodePrivateKey(b)
 	if err != nil {
 		return PrivateKey{}, err
 	}
 
 	return PrivateKey{
+		algo:       sigAlgo,
 		privateKey: privKey,
 	}, nil
 }
@@ -278,12 +334,18 @@ func DecodePrivateKeyHex(sigAlgo SignatureAlgorithm, s string) (PrivateKey, erro
 
 // DecodePublicKey decodes a raw byte encoded public key with the given signature algorithm.
 func DecodePublicKey(sigAlgo SignatureAlgorithm, b []byte) (PublicKey, error) {
-	pubKey, err := crypto.DecodePublicKey(crypto.SigningAlgorithm(sigAlgo), b)
+	factory, ok := signatureAlgorithmFactory(sigAlgo)
+	if !ok {
+		return PublicKey{}, fmt.Errorf("crypto: Go SDK does not support %s algorithm", sigAlgo)
+	}
+
+	pubKey, err := factory.DecodePublicKey(b)
 	if err != nil {
 		return PublicKey{}, err
 	}
 
 	return PublicKey{
+		algo:      sigAlgo,
 		publicKey: pubKey,
 	}, nil
 }
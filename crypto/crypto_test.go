@@ -19,6 +19,7 @@
 package crypto_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -128,3 +129,143 @@ func makeSeed(l int) []byte {
 	}
 	return seed
 }
+
+// fakeSignatureAlgorithm is an insecure signature scheme used to test algorithm registration.
+//
+// A signature is the hash of the key followed by the message, and the public key is equal
+// to the private key.
+type fakeSignatureAlgorithm struct{}
+
+func (fakeSignatureAlgorithm) Name() string {
+	return "FAKE"
+}
+
+func (fakeSignatureAlgorithm) CompatibleHashAlgorithm(hashAlgo crypto.HashAlgorithm) bool {
+	return hashAlgo == crypto.SHA3_256
+}
+
+func (fakeSignatureAlgorithm) GeneratePrivateKey(seed []byte) (crypto.PrivateKeyImplementation, error) {
+	return fakeKey(seed), nil
+}
+
+func (fakeSignatureAlgorithm) DecodePrivateKey(b []byte) (crypto.PrivateKeyImplementation, error) {
+	return fakeKey(b), nil
+}
+
+func (fakeSignatureAlgorithm) DecodePublicKey(b []byte) (crypto.PublicKeyImplementation, error) {
+	return fakeKey(b), nil
+}
+
+type fakeKey []byte
+
+func (k fakeKey) Sign(message []byte, hasher crypto.Hasher) ([]byte, error) {
+	return hasher.ComputeHash(append(append([]byte{}, k...), message...)), nil
+}
+
+func (k fakeKey) Verify(sig, message []byte, hasher crypto.Hasher) (bool, error) {
+	expected, _ := k.Sign(message, hasher)
+	return bytes.Equal(expected, sig), nil
+}
+
+func (k fakeKey) PublicKey() crypto.PublicKeyImplementation {
+	return k
+}
+
+func (k fakeKey) Encode() []byte {
+	return k
+}
+
+func TestRegisterSignatureAlgorithm(t *testing.T) {
+	fakeAlgo := crypto.SignatureAlgorithm(100)
+
+	err := crypto.RegisterSignatureAlgorithm(fakeAlgo, fakeSignatureAlgorithm{})
+	require.NoError(t, err)
+
+	t.Run("Already registered", func(t *testing.T) {
+		err := crypto.RegisterSignatureAlgorithm(fakeAlgo, fakeSignatureAlgorithm{})
+		assert.Error(t, err)
+
+		err = crypto.RegisterSignatureAlgorithm(crypto.ECDSA_P256, fakeSignatureAlgorithm{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Names", func(t *testing.T) {
+		assert.Equal(t, "FAKE", fakeAlgo.String())
+		assert.Equal(t, fakeAlgo, crypto.StringToSignatureAlgorithm("FAKE"))
+	})
+
+	t.Run("Compatible algorithms", func(t *testing.T) {
+		assert.True(t, crypto.CompatibleAlgorithms(fakeAlgo, crypto.SHA3_256))
+		assert.False(t, crypto.CompatibleAlgorithms(fakeAlgo, crypto.SHA2_256))
+	})
+
+	t.Run("Sign and verify", func(t *testing.T) {
+		sk, err := crypto.GeneratePrivateKey(fakeAlgo, makeSeed(crypto.MinSeedLength))
+		require.NoError(t, err)
+		assert.Equal(t, fakeAlgo, sk.Algorithm())
+
+		signer := crypto.NewInMemorySigner(sk, crypto.SHA3_256)
+
+		message := []byte("hello world")
+
+		sig, err := signer.Sign(message)
+		require.NoError(t, err)
+
+		pk, err := crypto.DecodePublicKey(fakeAlgo, sk.PublicKey().Encode())
+		require.NoError(t, err)
+		assert.Equal(t, fakeAlgo, pk.Algorithm())
+
+		valid, err := pk.Verify(sig, message, crypto.NewSHA3_256())
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		valid, err = pk.Verify(sig, []byte("goodbye world"), crypto.NewSHA3_256())
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+}
+
+func TestNewDeterministicSigner(t *testing.T) {
+	algos := []struct {
+		sigAlgo  crypto.SignatureAlgorithm
+		hashAlgo crypto.HashAlgorithm
+	}{
+		{crypto.ECDSA_P256, crypto.SHA3_256},
+		{crypto.ECDSA_secp256k1, crypto.SHA2_256},
+	}
+
+	seed := makeSeed(crypto.MinSeedLength)
+	message := []byte("hello world")
+
+	for _, algo := range algos {
+		t.Run(algo.sigAlgo.String(), func(t *testing.T) {
+			signerA, err := crypto.NewDeterministicSigner(seed, algo.sigAlgo, algo.hashAlgo)
+			require.NoError(t, err)
+
+			signerB, err := crypto.NewDeterministicSigner(seed, algo.sigAlgo, algo.hashAlgo)
+			require.NoError(t, err)
+
+			assert.Equal(t, signerA.PublicKey().Encode(), signerB.PublicKey().Encode())
+
+			sigA, err := signerA.Sign(message)
+			require.NoError(t, err)
+
+			sigB, err := signerB.Sign(message)
+			require.NoError(t, err)
+
+			assert.Equal(t, sigA, sigB)
+
+			hasher, err := crypto.NewHasher(algo.hashAlgo)
+			require.NoError(t, err)
+
+			valid, err := signerA.PublicKey().Verify(sigA, message, hasher)
+			require.NoError(t, err)
+			assert.True(t, valid)
+		})
+	}
+
+	t.Run("Seed length too short", func(t *testing.T) {
+		_, err := crypto.NewDeterministicSigner(makeSeed(crypto.MinSeedLength/2), crypto.ECDSA_P256, crypto.SHA3_256)
+		assert.Error(t, err)
+	})
+}
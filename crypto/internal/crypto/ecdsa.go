@@ -28,7 +28,10 @@ package crypto
 import (
 	goecdsa "crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/big"
@@ -113,6 +116,84 @@ func (sk *PrKeyECDSA) Sign(data []byte, alg hash.Hasher) (Signature, error) {
 	return sk.signHash(h)
 }
 
+// SignDeterministic signs an array of bytes using a nonce derived from the private key
+// and the message hash instead of a random nonce.
+//
+// The same key and message always produce the same signature. The nonce derivation is
+// not RFC 6979 and is only meant to produce reproducible signatures in tests.
+func (sk *PrKeyECDSA) SignDeterministic(data []byte, alg hash.Hasher) (Signature, error) {
+	if alg == nil {
+		return nil, errors.New("Sign requires a Hasher")
+	}
+	h := alg.ComputeHash(data)
+
+	curve := sk.alg.curve
+	N := curve.Params().N
+	Nlen := bitsToBytes(N.BitLen())
+	d := sk.goPrKey.D
+	e := hashToInt(h, curve)
+
+	dBytes := make([]byte, Nlen)
+	skBytes := d.Bytes()
+	copy(dBytes[Nlen-len(skBytes):], skBytes)
+
+	for counter := uint32(0); ; counter++ {
+		// derive the nonce k from the private key, the hash and a counter
+		mac := hmac.New(sha256.New, dBytes)
+		_, _ = mac.Write(h)
+		var counterBytes [4]byte
+		binary.BigEndian.PutUint32(counterBytes[:], counter)
+		_, _ = mac.Write(counterBytes[:])
+
+		k := new(big.Int).SetBytes(mac.Sum(nil))
+		k.Mod(k, N)
+		if k.Sign() == 0 {
+			continue
+		}
+
+		// r = (k*G).x mod N
+		x, _ := curve.ScalarBaseMult(k.Bytes())
+		r := new(big.Int).Mod(x, N)
+		if r.Sign() == 0 {
+			continue
+		}
+
+		// s = k^-1 * (e + r*d) mod N
+		s := new(big.Int).Mul(r, d)
+		s.Add(s, e)
+		s.Mul(s, new(big.Int).ModInverse(k, N))
+		s.Mod(s, N)
+		if s.Sign() == 0 {
+			continue
+		}
+
+		rBytes := r.Bytes()
+		sBytes := s.Bytes()
+		signature := make([]byte, 2*Nlen)
+		// pad the signature with zeroes
+		copy(signature[Nlen-len(rBytes):], rBytes)
+		copy(signature[2*Nlen-len(sBytes):], sBytes)
+		return signature, nil
+	}
+}
+
+// hashToInt converts a hash value to an integer, truncated to the bit length
+// of the curve order as in FIPS 186-4.
+func hashToInt(h hash.Hash, curve elliptic.Curve) *big.Int {
+	orderBits := curve.Params().N.BitLen()
+	orderBytes := bitsToBytes(orderBits)
+	if len(h) > orderBytes {
+		h = h[:orderBytes]
+	}
+
+	ret := new(big.Int).SetBytes(h)
+	excess := len(h)*8 - orderBits
+	if excess > 0 {
+		ret.Rsh(ret, uint(excess))
+	}
+	return ret
+}
+
 // verifyHash implements ECDSA signature verification
 func (pk *PubKeyECDSA) verifyHash(sig Signature, h hash.Hash) (bool, error) {
 	var r big.Int
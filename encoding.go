@@ -0,0 +1,445 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// Operations reported to the encoding metrics hook.
+const (
+	EncodingOpEncode = "encode"
+	EncodingOpDecode = "decode"
+	EncodingOpID     = "id"
+)
+
+// encodingMetricsHook holds an encodingMetricsHookFunc, or nil if no hook is set.
+var encodingMetricsHook atomic.Value
+
+type encodingMetricsHookFunc struct {
+	f func(op string, d time.Duration)
+}
+
+// SetEncodingMetricsHook sets a callback that receives the duration of each transaction
+// Encode, DecodeFromBytes and ID call.
+//
+// The operation is reported as EncodingOpEncode, EncodingOpDecode or EncodingOpID. Since ID
+// encodes the transaction, an ID call also reports an encode operation.
+//
+// The hook is called synchronously and must be safe for concurrent use. Passing nil
+// removes the hook, after which no timing is measured.
+func SetEncodingMetricsHook(hook func(op string, d time.Duration)) {
+	encodingMetricsHook.Store(encodingMetricsHookFunc{f: hook})
+}
+
+// loadEncodingMetricsHook returns the current encoding metrics hook, or nil if none is set.
+func loadEncodingMetricsHook() func(op string, d time.Duration) {
+	hook, _ := encodingMetricsHook.Load().(encodingMetricsHookFunc)
+	return hook.f
+}
+
+// EncodingDiff compares two encoded byte sequences.
+//
+// The first return value is the offset of the first byte that differs between a and b,
+// or -1 if the sequences are identical. If one sequence is a prefix of the other, the
+// offset is the length of the shorter sequence.
+//
+// The second return value is the number of positions (up to the length of the shorter
+// sequence) at which both sequences hold the same byte. A single corrupted byte therefore
+// produces a count of one less than the length, whereas an inserted or dropped byte
+// usually produces a much lower count.
+func EncodingDiff(a, b []byte) (firstDiffOffset int, equalLen int) {
+	firstDiffOffset = -1
+
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	for i := 0; i < n; i++ {
+		if a[i] == b[i] {
+			equalLen++
+			continue
+		}
+
+		if firstDiffOffset == -1 {
+			firstDiffOffset = i
+		}
+	}
+
+	if firstDiffOffset == -1 && len(a) != len(b) {
+		firstDiffOffset = n
+	}
+
+	return firstDiffOffset, equalLen
+}
+
+// CompareTransactions returns the names of the transaction fields that differ between a and b.
+//
+// The returned list is empty if both transactions are equal.
+func CompareTransactions(a, b *Transaction) []string {
+	diff := make([]string, 0)
+
+	if !bytes.Equal(a.Script, b.Script) {
+		diff = append(diff, "Script")
+	}
+
+	if !equalByteSlices(a.Arguments, b.Arguments) {
+		diff = append(diff, "Arguments")
+	}
+
+	if a.ReferenceBlockID != b.ReferenceBlockID {
+		diff = append(diff, "ReferenceBlockID")
+	}
+
+	if a.GasLimit != b.GasLimit {
+		diff = append(diff, "GasLimit")
+	}
+
+	if a.ProposalKey != b.ProposalKey {
+		diff = append(diff, "ProposalKey")
+	}
+
+	if a.Payer != b.Payer {
+		diff = append(diff, "Payer")
+	}
+
+	if !equalAddresses(a.Authorizers, b.Authorizers) {
+		diff = append(diff, "Authorizers")
+	}
+
+	if !equalSignatures(a.PayloadSignatures, b.PayloadSignatures) {
+		diff = append(diff, "PayloadSignatures")
+	}
+
+	if !equalSignatures(a.EnvelopeSignatures, b.EnvelopeSignatures) {
+		diff = append(diff, "EnvelopeSignatures")
+	}
+
+	return diff
+}
+
+func equalByteSlices(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalAddresses(a, b []Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalSignatures(a, b []TransactionSignature) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Address != b[i].Address ||
+			a[i].SignerIndex != b[i].SignerIndex ||
+			a[i].KeyIndex != b[i].KeyIndex ||
+			!bytes.Equal(a[i].Signature, b[i].Signature) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MaxTransactionRecordSize is the maximum size in bytes of a transaction encoding written
+// by WriteTransactions or read by ReadTransactions.
+//
+// It matches the default maximum transaction size accepted by Flow access nodes.
+const MaxTransactionRecordSize = 1500000
+
+// WriteTransactions writes a sequence of transactions to w.
+//
+// Each transaction is written as its canonical RLP encoding, prefixed by the length of
+// the encoding as a 4-byte big-endian integer. The output can be read back with ReadTransactions.
+//
+// An error is returned if the encoding of a transaction is larger than MaxTransactionRecordSize.
+func WriteTransactions(w io.Writer, txs []*Transaction) error {
+	for i, tx := range txs {
+		b := tx.Encode()
+
+		if len(b) > MaxTransactionRecordSize {
+			return fmt.Errorf(
+				"transaction %d encoding of %d bytes exceeds the limit of %d bytes",
+				i,
+				len(b),
+				MaxTransactionRecordSize,
+			)
+		}
+
+		var length [4]byte
+		binary.BigEndian.PutUint32(length[:], uint32(len(b)))
+
+		if _, err := w.Write(length[:]); err != nil {
+			return fmt.Errorf("failed to write transaction %d: %w", i, err)
+		}
+
+		if _, err := w.Write(b); err != nil {
+			return fmt.Errorf("failed to write transaction %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// ReadTransactions reads a sequence of transactions written by WriteTransactions from r.
+//
+// Reading stops without error when r is exhausted at a record boundary. An error is
+// returned if a record is truncated, is larger than MaxTransactionRecordSize or cannot
+// be decoded.
+func ReadTransactions(r io.Reader) ([]*Transaction, error) {
+	txs := make([]*Transaction, 0)
+
+	for {
+		var length [4]byte
+
+		_, err := io.ReadFull(r, length[:])
+		if err == io.EOF {
+			return txs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read length of transaction %d: %w", len(txs), err)
+		}
+
+		size := binary.BigEndian.Uint32(length[:])
+		if size > MaxTransactionRecordSize {
+			return nil, fmt.Errorf(
+				"length %d of transaction %d exceeds the limit of %d bytes",
+				size,
+				len(txs),
+				MaxTransactionRecordSize,
+			)
+		}
+
+		b := make([]byte, size)
+
+		_, err = io.ReadFull(r, b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read transaction %d: %w", len(txs), err)
+		}
+
+		tx := NewTransaction()
+
+		err = tx.DecodeFromBytes(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode transaction %d: %w", len(txs), err)
+		}
+
+		txs = append(txs, tx)
+	}
+}
+
+const (
+	// TransactionEncodingV0 is the unversioned canonical RLP encoding produced by Encode.
+	TransactionEncodingV0 byte = 0
+	// TransactionEncodingV1 is the canonical RLP encoding prefixed by its version byte.
+	TransactionEncodingV1 byte = 1
+)
+
+// rlpListPrefix is the smallest first byte of an RLP encoded list.
+const rlpListPrefix = 0xc0
+
+// EncodeVersioned returns the canonical encoding of this transaction prefixed by a
+// one-byte encoding version.
+//
+// Versioned encodings should be used when storing transactions, so that stored bytes
+// remain decodable if the canonical form changes. They can be decoded with DecodeVersioned.
+func (t *Transaction) EncodeVersioned() []byte {
+	return append([]byte{TransactionEncodingV1}, t.Encode()...)
+}
+
+// DecodeVersioned decodes a transaction encoded with EncodeVersioned.
+//
+// Unversioned encodings produced by Encode are decoded as TransactionEncodingV0.
+func DecodeVersioned(b []byte) (*Transaction, error) {
+	if len(b) == 0 {
+		return nil, fmt.Errorf("encoded transaction is empty")
+	}
+
+	tx := NewTransaction()
+
+	// an RLP list cannot start with a version byte
+	if b[0] >= rlpListPrefix {
+		if err := tx.DecodeFromBytes(b); err != nil {
+			return nil, err
+		}
+
+		return tx, nil
+	}
+
+	switch version := b[0]; version {
+	case TransactionEncodingV1:
+		if err := tx.DecodeFromBytes(b[1:]); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported transaction encoding version %d", version)
+	}
+
+	return tx, nil
+}
+
+// FieldBytes is a canonical transaction field paired with its RLP encoding.
+type FieldBytes struct {
+	Name  string
+	Bytes []byte
+}
+
+// ExplainEncoding returns the RLP encoding of each canonical field of a transaction.
+//
+// The payload fields are returned in canonical order, followed by the payload and envelope
+// signatures. The concatenated payload field encodings form the content of the payload
+// message, and the signature encodings form the tail of the full transaction encoding.
+//
+// Comparing the output against an encoding produced by another SDK pinpoints the field
+// responsible for a mismatch.
+func ExplainEncoding(tx *Transaction) []FieldBytes {
+	payload := reflect.ValueOf(tx.payloadCanonicalForm())
+
+	fields := make([]FieldBytes, 0, payload.NumField()+2)
+
+	for i := 0; i < payload.NumField(); i++ {
+		fields = append(fields, FieldBytes{
+			Name:  payload.Type().Field(i).Name,
+			Bytes: mustRLPEncode(payload.Field(i).Interface()),
+		})
+	}
+
+	fields = append(fields,
+		FieldBytes{
+			Name:  "PayloadSignatures",
+			Bytes: mustRLPEncode(signaturesList(tx.PayloadSignatures).canonicalForm()),
+		},
+		FieldBytes{
+			Name:  "EnvelopeSignatures",
+			Bytes: mustRLPEncode(signaturesList(tx.EnvelopeSignatures).canonicalForm()),
+		},
+	)
+
+	return fields
+}
+
+// Observations reported by InspectEncoding.
+const (
+	EncodingObservationUndecodable       = "transaction could not be decoded"
+	EncodingObservationLegacyAuthorizers = "authorizers all equal proposal key address (legacy bug)"
+	EncodingObservationUnsortedPayload   = "payload signatures not sorted"
+	EncodingObservationUnsortedEnvelope  = "envelope signatures not sorted"
+	EncodingObservationUnversioned       = "encoding is not versioned"
+)
+
+// An EncodingReport describes anomalies found in an encoded transaction.
+type EncodingReport struct {
+	// Version is the encoding version of the transaction.
+	Version byte
+	// Transaction is the decoded transaction, or nil if it could not be decoded.
+	Transaction *Transaction
+	// Observations lists the anomalies found in the encoding.
+	Observations []string
+}
+
+// HasObservation returns true if the report includes the given observation.
+func (r EncodingReport) HasObservation(observation string) bool {
+	for _, o := range r.Observations {
+		if o == observation {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InspectEncoding decodes a transaction encoded with Encode or EncodeVersioned and reports
+// anomalies that hint at the SDK version or bug that produced it.
+//
+// This function is intended for triaging stored transactions and never returns an error;
+// bytes that cannot be decoded are reported as EncodingObservationUndecodable.
+func InspectEncoding(bs []byte) EncodingReport {
+	report := EncodingReport{
+		Observations: make([]string, 0),
+	}
+
+	if len(bs) > 0 && bs[0] >= rlpListPrefix {
+		report.Version = TransactionEncodingV0
+		report.Observations = append(report.Observations, EncodingObservationUnversioned)
+	} else if len(bs) > 0 {
+		report.Version = bs[0]
+	}
+
+	tx, err := DecodeVersioned(bs)
+	if err != nil {
+		report.Observations = append(report.Observations, EncodingObservationUndecodable)
+		return report
+	}
+
+	report.Transaction = tx
+
+	if len(tx.Authorizers) > 1 {
+		legacy := true
+		for _, authorizer := range tx.Authorizers {
+			if authorizer != tx.ProposalKey.Address {
+				legacy = false
+				break
+			}
+		}
+
+		if legacy {
+			report.Observations = append(report.Observations, EncodingObservationLegacyAuthorizers)
+		}
+	}
+
+	if !sort.SliceIsSorted(tx.PayloadSignatures, compareSignatures(tx.PayloadSignatures)) {
+		report.Observations = append(report.Observations, EncodingObservationUnsortedPayload)
+	}
+
+	if !sort.SliceIsSorted(tx.EnvelopeSignatures, compareSignatures(tx.EnvelopeSignatures)) {
+		report.Observations = append(report.Observations, EncodingObservationUnsortedEnvelope)
+	}
+
+	return report
+}
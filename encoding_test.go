@@ -0,0 +1,304 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portto/blocto-flow-go-sdk"
+	"github.com/portto/blocto-flow-go-sdk/test"
+)
+
+func TestEncodingDiff(t *testing.T) {
+	t.Run("Identical", func(t *testing.T) {
+		offset, equalLen := flow.EncodingDiff([]byte{1, 2, 3}, []byte{1, 2, 3})
+		assert.Equal(t, -1, offset)
+		assert.Equal(t, 3, equalLen)
+	})
+
+	t.Run("One byte difference", func(t *testing.T) {
+		txA := baseTx()
+		txB := baseTx().SetGasLimit(txA.GasLimit + 1)
+
+		a := txA.Encode()
+		b := txB.Encode()
+
+		offset, equalLen := flow.EncodingDiff(a, b)
+		assert.NotEqual(t, -1, offset)
+		assert.NotEqual(t, a[offset], b[offset])
+		assert.Equal(t, a[:offset], b[:offset])
+		assert.Equal(t, len(a)-1, equalLen)
+
+		assert.Equal(t, []string{"GasLimit"}, flow.CompareTransactions(txA, txB))
+	})
+
+	t.Run("Prefix", func(t *testing.T) {
+		offset, equalLen := flow.EncodingDiff([]byte{1, 2}, []byte{1, 2, 3})
+		assert.Equal(t, 2, offset)
+		assert.Equal(t, 2, equalLen)
+	})
+}
+
+func TestCompareTransactions(t *testing.T) {
+	t.Run("Equal", func(t *testing.T) {
+		assert.Empty(t, flow.CompareTransactions(baseTx(), baseTx()))
+	})
+
+	t.Run("Multiple fields", func(t *testing.T) {
+		txA := baseTx()
+		txB := baseTx().
+			SetPayer(flow.HexToAddress("02")).
+			AddAuthorizer(flow.HexToAddress("02"))
+
+		assert.Equal(t, []string{"Payer", "Authorizers"}, flow.CompareTransactions(txA, txB))
+	})
+}
+
+func TestWriteReadTransactions(t *testing.T) {
+	transactions := test.TransactionGenerator()
+
+	t.Run("Round trip", func(t *testing.T) {
+		txs := make([]*flow.Transaction, 100)
+		for i := range txs {
+			txs[i] = transactions.New()
+		}
+
+		var buf bytes.Buffer
+
+		err := flow.WriteTransactions(&buf, txs)
+		require.NoError(t, err)
+
+		decodedTxs, err := flow.ReadTransactions(&buf)
+		require.NoError(t, err)
+		require.Len(t, decodedTxs, len(txs))
+
+		for i, tx := range txs {
+			assert.Equal(t, tx.ID(), decodedTxs[i].ID())
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		txs, err := flow.ReadTransactions(&bytes.Buffer{})
+		require.NoError(t, err)
+		assert.Empty(t, txs)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := flow.WriteTransactions(&buf, []*flow.Transaction{transactions.New()})
+		require.NoError(t, err)
+
+		truncated := buf.Bytes()[:buf.Len()-1]
+
+		_, err = flow.ReadTransactions(bytes.NewReader(truncated))
+		assert.Error(t, err)
+	})
+
+	t.Run("Oversized record", func(t *testing.T) {
+		oversized := []byte{0xff, 0xff, 0xff, 0xff, 0xc0}
+
+		_, err := flow.ReadTransactions(bytes.NewReader(oversized))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeds the limit")
+	})
+
+	t.Run("Oversized transaction", func(t *testing.T) {
+		tx := transactions.New().SetScript(make([]byte, flow.MaxTransactionRecordSize))
+
+		var buf bytes.Buffer
+
+		err := flow.WriteTransactions(&buf, []*flow.Transaction{tx})
+		require.Error(t, err)
+		assert.Equal(t, 0, buf.Len())
+	})
+}
+
+func TestEncodeDecodeVersioned(t *testing.T) {
+	transactions := test.TransactionGenerator()
+
+	t.Run("Version 1", func(t *testing.T) {
+		tx := transactions.New()
+
+		b := tx.EncodeVersioned()
+		assert.Equal(t, flow.TransactionEncodingV1, b[0])
+		assert.Equal(t, tx.Encode(), b[1:])
+
+		decodedTx, err := flow.DecodeVersioned(b)
+		require.NoError(t, err)
+		assert.Equal(t, tx.ID(), decodedTx.ID())
+	})
+
+	t.Run("Unversioned", func(t *testing.T) {
+		tx := transactions.New()
+
+		decodedTx, err := flow.DecodeVersioned(tx.Encode())
+		require.NoError(t, err)
+		assert.Equal(t, tx.ID(), decodedTx.ID())
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		b := append([]byte{42}, transactions.New().Encode()...)
+
+		_, err := flow.DecodeVersioned(b)
+		assert.Error(t, err)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := flow.DecodeVersioned(nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestExplainEncoding(t *testing.T) {
+	tx := baseTx()
+
+	fields := flow.ExplainEncoding(tx)
+
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+
+	assert.Equal(t, []string{
+		"Script",
+		"Arguments",
+		"ReferenceBlockID",
+		"GasLimit",
+		"ProposalKeyAddress",
+		"ProposalKeyIndex",
+		"ProposalKeySequenceNumber",
+		"Payer",
+		"Authorizers",
+		"PayloadSignatures",
+		"EnvelopeSignatures",
+	}, names)
+
+	var payload []byte
+	for _, field := range fields[:9] {
+		payload = append(payload, field.Bytes...)
+	}
+
+	// the payload message is an RLP list header followed by the payload fields
+	assert.True(t, bytes.HasSuffix(tx.PayloadMessage(), payload))
+
+	var signatures []byte
+	for _, field := range fields[9:] {
+		signatures = append(signatures, field.Bytes...)
+	}
+
+	// the full encoding ends with the payload and envelope signatures
+	assert.True(t, bytes.HasSuffix(tx.Encode(), signatures))
+	assert.True(t, bytes.Contains(tx.Encode(), tx.PayloadMessage()))
+}
+
+func TestSetEncodingMetricsHook(t *testing.T) {
+	ops := make([]string, 0)
+
+	flow.SetEncodingMetricsHook(func(op string, d time.Duration) {
+		ops = append(ops, op)
+	})
+	defer flow.SetEncodingMetricsHook(nil)
+
+	tx := test.TransactionGenerator().New()
+
+	encoded := tx.Encode()
+	assert.Equal(t, []string{flow.EncodingOpEncode}, ops)
+
+	err := flow.NewTransaction().DecodeFromBytes(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, []string{flow.EncodingOpEncode, flow.EncodingOpDecode}, ops)
+
+	flow.SetEncodingMetricsHook(nil)
+
+	tx.Encode()
+	assert.Len(t, ops, 2)
+}
+
+// legacyDecodeFromPayloadBytes reproduces the decoder bug that replaced every authorizer
+// with the proposal key address.
+func legacyDecodeFromPayloadBytes(t *testing.T, b []byte) *flow.Transaction {
+	tx := flow.NewTransaction()
+	require.NoError(t, tx.DecodeFromPayloadBytes(b))
+
+	for i := range tx.Authorizers {
+		tx.Authorizers[i] = tx.ProposalKey.Address
+	}
+
+	return tx
+}
+
+func TestInspectEncoding(t *testing.T) {
+	addresses := test.AddressGenerator()
+
+	proposer := addresses.New()
+	payer := addresses.New()
+
+	newTx := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript([]byte("transaction {}")).
+			SetProposalKey(proposer, 0, 42).
+			SetPayer(payer).
+			AddAuthorizer(addresses.New()).
+			AddAuthorizer(addresses.New())
+	}
+
+	t.Run("Clean encoding", func(t *testing.T) {
+		report := flow.InspectEncoding(newTx().EncodeVersioned())
+
+		assert.Equal(t, flow.TransactionEncodingV1, report.Version)
+		assert.NotNil(t, report.Transaction)
+		assert.Empty(t, report.Observations)
+	})
+
+	t.Run("Legacy authorizers", func(t *testing.T) {
+		legacy := legacyDecodeFromPayloadBytes(t, newTx().EnvelopeMessage())
+
+		report := flow.InspectEncoding(legacy.Encode())
+
+		assert.Equal(t, flow.TransactionEncodingV0, report.Version)
+		assert.True(t, report.HasObservation(flow.EncodingObservationUnversioned))
+		assert.True(t, report.HasObservation(flow.EncodingObservationLegacyAuthorizers))
+	})
+
+	t.Run("Unsorted signatures", func(t *testing.T) {
+		tx := newTx()
+		tx.PayloadSignatures = []flow.TransactionSignature{
+			{Address: tx.Authorizers[1], SignerIndex: 3, KeyIndex: 0, Signature: []byte{1}},
+			{Address: tx.Authorizers[0], SignerIndex: 2, KeyIndex: 0, Signature: []byte{2}},
+		}
+
+		report := flow.InspectEncoding(tx.EncodeVersioned())
+
+		assert.True(t, report.HasObservation(flow.EncodingObservationUnsortedPayload))
+		assert.False(t, report.HasObservation(flow.EncodingObservationUnsortedEnvelope))
+	})
+
+	t.Run("Undecodable", func(t *testing.T) {
+		report := flow.InspectEncoding([]byte{0xff})
+
+		assert.Nil(t, report.Transaction)
+		assert.True(t, report.HasObservation(flow.EncodingObservationUndecodable))
+	})
+}
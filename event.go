@@ -65,6 +65,77 @@ func (e Event) Encode() []byte {
 	return mustRLPEncode(&temp)
 }
 
+// ValidatePayload checks that the event value is consistent with the event type.
+//
+// An event payload is invalid for the following reasons:
+// - It does not declare an event type
+// - Its type ID does not match the Type field of this event
+// - It does not contain a value for every field declared by its type
+func (e Event) ValidatePayload() error {
+	eventType := e.Value.EventType
+	if eventType == nil {
+		return fmt.Errorf("event payload for %s does not declare a type", e.Type)
+	}
+
+	if eventType.TypeID != e.Type {
+		return fmt.Errorf(
+			"event payload type %s does not match event type %s",
+			eventType.TypeID,
+			e.Type,
+		)
+	}
+
+	if len(e.Value.Fields) != len(eventType.Fields) {
+		return fmt.Errorf(
+			"event payload for %s has %d fields, but its type declares %d",
+			e.Type,
+			len(e.Value.Fields),
+			len(eventType.Fields),
+		)
+	}
+
+	for i, field := range e.Value.Fields {
+		if field == nil {
+			return fmt.Errorf(
+				"event payload for %s is missing a value for field %s",
+				e.Type,
+				eventType.Fields[i].Identifier,
+			)
+		}
+	}
+
+	return nil
+}
+
+// ValidateAllEvents validates the event payloads of a batch of transaction results.
+//
+// The returned slice is aligned with results: for each result, the error describes the
+// first event with an invalid payload, or is nil if all of its events are valid. Event
+// payloads are validated with Event.ValidatePayload.
+func ValidateAllEvents(results []*TransactionResult) []error {
+	errs := make([]error, len(results))
+
+	for i, result := range results {
+		errs[i] = validateEvents(result)
+	}
+
+	return errs
+}
+
+func validateEvents(result *TransactionResult) error {
+	if result == nil {
+		return fmt.Errorf("transaction result is nil")
+	}
+
+	for i, event := range result.Events {
+		if err := event.ValidatePayload(); err != nil {
+			return fmt.Errorf("invalid payload for event %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
 // An AccountCreatedEvent is emitted when a transaction creates a new Flow account.
 //
 // This event contains the following fields:
@@ -0,0 +1,81 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portto/blocto-flow-go-sdk"
+	"github.com/portto/blocto-flow-go-sdk/test"
+)
+
+func TestEvent_ValidatePayload(t *testing.T) {
+	events := test.EventGenerator()
+
+	t.Run("Valid", func(t *testing.T) {
+		event := events.New()
+
+		assert.NoError(t, event.ValidatePayload())
+	})
+
+	t.Run("Mismatched type", func(t *testing.T) {
+		event := events.New()
+		event.Type = "test.BarEvent"
+
+		assert.Error(t, event.ValidatePayload())
+	})
+
+	t.Run("Missing type", func(t *testing.T) {
+		event := events.New()
+		event.Value.EventType = nil
+
+		assert.Error(t, event.ValidatePayload())
+	})
+
+	t.Run("Missing field", func(t *testing.T) {
+		event := events.New()
+		event.Value.Fields = []cadence.Value{event.Value.Fields[0]}
+
+		assert.Error(t, event.ValidatePayload())
+	})
+}
+
+func TestValidateAllEvents(t *testing.T) {
+	events := test.EventGenerator()
+
+	invalidEvent := events.New()
+	invalidEvent.Value.EventType = nil
+
+	results := []*flow.TransactionResult{
+		{Events: []flow.Event{events.New(), events.New()}},
+		{Events: []flow.Event{events.New(), invalidEvent}},
+		nil,
+	}
+
+	errs := flow.ValidateAllEvents(results)
+	require.Len(t, errs, 3)
+
+	assert.NoError(t, errs[0])
+	assert.Error(t, errs[1])
+	assert.Error(t, errs[2])
+}
@@ -20,6 +20,8 @@ package flow
 
 import (
 	"encoding/hex"
+	"flag"
+	"fmt"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/rlp"
@@ -33,6 +35,16 @@ type Identifier [32]byte
 // EmptyID is the empty identifier.
 var EmptyID = Identifier{}
 
+// EmptyIdentifier is the empty identifier.
+//
+// It is equal to EmptyID, and is named to match EmptyAddress.
+var EmptyIdentifier = EmptyID
+
+// IsZero returns true if this identifier is the empty identifier.
+func (i Identifier) IsZero() bool {
+	return i == EmptyIdentifier
+}
+
 // Bytes returns the bytes representation of this identifier.
 func (i Identifier) Bytes() []byte {
 	return i[:]
@@ -84,6 +96,9 @@ func (id ChainID) String() string {
 	return string(id)
 }
 
+// MaxGasLimit is the maximum transaction gas limit accepted by Flow networks.
+const MaxGasLimit uint64 = 9999
+
 // entityHasher is a thread-safe hasher used to hash Flow entities.
 type entityHasher struct {
 	mut    sync.Mutex
@@ -96,6 +111,17 @@ func (h *entityHasher) ComputeHash(b []byte) crypto.Hash {
 	return h.hasher.ComputeHash(b)
 }
 
+// swapHasher replaces the hasher and returns the previous one.
+func (h *entityHasher) swapHasher(hasher crypto.Hasher) crypto.Hasher {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	previous := h.hasher
+	h.hasher = hasher
+
+	return previous
+}
+
 // defaultEntityHasher is the default hasher used to compute Flow identifiers.
 var defaultEntityHasher *entityHasher
 
@@ -105,6 +131,38 @@ func init() {
 	}
 }
 
+// SetDefaultEntityHasher replaces the hasher used to compute entity identifiers
+// (e.g. Transaction.ID) for the entire package, and returns a function that restores
+// the previous hasher.
+//
+// This function is intended for tests against private networks that use a modified
+// hashing scheme, and returns an error if it is called outside of a test binary built by
+// go test. Identifiers computed with a custom hasher will not match those computed by
+// Flow mainnet or testnet nodes, and the hasher is shared by all goroutines in the
+// process, so tests that replace it must not run in parallel with tests that compute
+// identifiers. The returned function should be deferred to restore the hasher:
+//
+//	restore, err := flow.SetDefaultEntityHasher(hasher)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer restore()
+func SetDefaultEntityHasher(h crypto.Hasher) (restore func(), err error) {
+	if flag.Lookup("test.v") == nil {
+		return nil, fmt.Errorf("the default entity hasher can only be replaced in tests")
+	}
+
+	if h == nil {
+		return nil, fmt.Errorf("entity hasher cannot be nil")
+	}
+
+	previous := defaultEntityHasher.swapHasher(h)
+
+	return func() {
+		defaultEntityHasher.swapHasher(previous)
+	}, nil
+}
+
 func rlpEncode(v interface{}) ([]byte, error) {
 	return rlp.EncodeToBytes(v)
 }
@@ -0,0 +1,56 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portto/blocto-flow-go-sdk"
+	"github.com/portto/blocto-flow-go-sdk/crypto"
+)
+
+func TestSetDefaultEntityHasher(t *testing.T) {
+	t.Run("Swap and restore", func(t *testing.T) {
+		tx := baseTx()
+
+		defaultID := tx.ID()
+
+		restore, err := flow.SetDefaultEntityHasher(crypto.NewSHA2_256())
+		require.NoError(t, err)
+
+		customID := tx.ID()
+
+		assert.NotEqual(t, defaultID, customID)
+		assert.Equal(t, customID, tx.ID())
+		assert.Equal(t, flow.HashToID(crypto.NewSHA2_256().ComputeHash(tx.Encode())), customID)
+
+		restore()
+
+		assert.Equal(t, defaultID, tx.ID())
+	})
+
+	t.Run("Nil hasher", func(t *testing.T) {
+		restore, err := flow.SetDefaultEntityHasher(nil)
+		assert.Error(t, err)
+		assert.Nil(t, restore)
+	})
+}
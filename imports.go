@@ -0,0 +1,336 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// An ImportDecl is a single import declared by a Cadence script.
+type ImportDecl struct {
+	// ContractName is the name of the imported contract. It is empty if the
+	// declaration imports everything from its location.
+	ContractName string
+	// Location is the location the contract is imported from, as written in the
+	// script. It is either an address, a placeholder (e.g. 0xFUNGIBLETOKEN) or a
+	// quoted file path with the quotes removed. It is empty for built-in contracts.
+	Location string
+}
+
+// Address returns the address the contract is imported from.
+//
+// This function returns false if the location is not an account address, for
+// example if it is a placeholder that has not been replaced yet.
+func (d ImportDecl) Address() (Address, bool) {
+	if !has0xPrefix(d.Location) {
+		return EmptyAddress, false
+	}
+
+	h := d.Location[2:]
+	if len(h) == 0 || len(h) > 2*AddressLength {
+		return EmptyAddress, false
+	}
+
+	if len(h)%2 == 1 {
+		h = "0" + h
+	}
+
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return EmptyAddress, false
+	}
+
+	return BytesToAddress(b), true
+}
+
+var (
+	importIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	importLocationRegexp   = regexp.MustCompile(`^(0[xX][A-Za-z0-9_]+|"[^"]*")$`)
+)
+
+// Imports returns the import declarations of the transaction script.
+//
+// Each imported contract is returned as a separate declaration, in the order
+// in which it appears in the script.
+func (t *Transaction) Imports() ([]ImportDecl, error) {
+	return parseImports(t.Script)
+}
+
+func parseImports(script []byte) ([]ImportDecl, error) {
+	imports := make([]ImportDecl, 0)
+
+	for i, code := range scriptCodeLines(script) {
+		decl, ok := importDeclaration(code)
+		if !ok {
+			continue
+		}
+
+		decls, err := parseImportDeclaration(decl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid import on line %d: %w", i+1, err)
+		}
+
+		imports = append(imports, decls...)
+	}
+
+	return imports, nil
+}
+
+// scriptCodeLines splits a script into lines and replaces the comments in each line with
+// spaces, so that the remaining code is at the same offsets as in the original line.
+//
+// Line comments and nested block comments are removed. Comment markers inside string
+// literals are ignored.
+func scriptCodeLines(script []byte) []string {
+	lines := strings.Split(string(script), "\n")
+
+	depth := 0
+
+	for i, line := range lines {
+		code := []byte(line)
+		inString := false
+
+		for j := 0; j < len(code); j++ {
+			next := byte(0)
+			if j+1 < len(code) {
+				next = code[j+1]
+			}
+
+			switch {
+			case depth > 0:
+				if code[j] == '/' && next == '*' {
+					depth++
+					code[j], code[j+1] = ' ', ' '
+					j++
+				} else if code[j] == '*' && next == '/' {
+					depth--
+					code[j], code[j+1] = ' ', ' '
+					j++
+				} else {
+					code[j] = ' '
+				}
+			case inString:
+				if code[j] == '\\' {
+					j++
+				} else if code[j] == '"' {
+					inString = false
+				}
+			case code[j] == '"':
+				inString = true
+			case code[j] == '/' && next == '/':
+				for k := j; k < len(code); k++ {
+					code[k] = ' '
+				}
+				j = len(code)
+			case code[j] == '/' && next == '*':
+				depth++
+				code[j], code[j+1] = ' ', ' '
+				j++
+			}
+		}
+
+		lines[i] = string(code)
+	}
+
+	return lines
+}
+
+// importDeclaration returns the declaration of an import statement without the import
+// keyword and the optional trailing semicolon.
+//
+// This function returns false if the line is not an import statement.
+func importDeclaration(code string) (string, bool) {
+	text := strings.TrimSpace(code)
+
+	fields := strings.Fields(text)
+	if len(fields) == 0 || fields[0] != "import" {
+		return "", false
+	}
+
+	decl := strings.TrimSpace(text[len("import"):])
+	decl = strings.TrimSpace(strings.TrimSuffix(decl, ";"))
+
+	return decl, true
+}
+
+func parseImportDeclaration(decl string) ([]ImportDecl, error) {
+	names := decl
+	location := ""
+
+	if i := strings.Index(decl, " from "); i >= 0 {
+		names = strings.TrimSpace(decl[:i])
+		location = strings.TrimSpace(decl[i+len(" from "):])
+	} else if importLocationRegexp.MatchString(decl) {
+		names = ""
+		location = decl
+	}
+
+	if location != "" && !importLocationRegexp.MatchString(location) {
+		return nil, fmt.Errorf("invalid location %q", location)
+	}
+
+	location = strings.Trim(location, `"`)
+
+	if names == "" {
+		if location == "" {
+			return nil, fmt.Errorf("missing contract name or location")
+		}
+
+		return []ImportDecl{{Location: location}}, nil
+	}
+
+	decls := make([]ImportDecl, 0)
+
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+
+		if !importIdentifierRegexp.MatchString(name) {
+			return nil, fmt.Errorf("invalid contract name %q", name)
+		}
+
+		decls = append(decls, ImportDecl{
+			ContractName: name,
+			Location:     location,
+		})
+	}
+
+	return decls, nil
+}
+
+// ResolveImports replaces the import placeholders in a script with contract addresses.
+//
+// A placeholder is an import location that starts with 0x but is not a valid address,
+// such as 0xFUNGIBLETOKEN. Each placeholder is replaced by the address of the imported
+// contract in the given map, keyed by contract name. Imports from valid addresses and
+// file paths are left unchanged.
+//
+// This function returns an error if a placeholder import refers to a contract that is
+// not in the map.
+func ResolveImports(script []byte, contracts map[string]Address) ([]byte, error) {
+	lines := strings.Split(string(script), "\n")
+
+	for i, code := range scriptCodeLines(script) {
+		decl, ok := importDeclaration(code)
+		if !ok {
+			continue
+		}
+
+		decls, err := parseImportDeclaration(decl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid import on line %d: %w", i+1, err)
+		}
+
+		placeholder := decls[0].Location
+		if !has0xPrefix(placeholder) {
+			continue
+		}
+
+		if _, ok := decls[0].Address(); ok {
+			continue
+		}
+
+		address, err := resolvePlaceholder(decls, contracts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve import on line %d: %w", i+1, err)
+		}
+
+		line := lines[i]
+
+		j := strings.LastIndex(code, placeholder)
+		lines[i] = line[:j] + "0x" + address.Hex() + line[j+len(placeholder):]
+	}
+
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+func resolvePlaceholder(decls []ImportDecl, contracts map[string]Address) (Address, error) {
+	var address Address
+
+	for i, decl := range decls {
+		if decl.ContractName == "" {
+			return EmptyAddress, fmt.Errorf("cannot resolve %s without a contract name", decl.Location)
+		}
+
+		contractAddress, ok := contracts[decl.ContractName]
+		if !ok {
+			return EmptyAddress, fmt.Errorf("no known address for contract %s", decl.ContractName)
+		}
+
+		if i > 0 && contractAddress != address {
+			return EmptyAddress, fmt.Errorf(
+				"contracts imported from %s are deployed to different addresses",
+				decl.Location,
+			)
+		}
+
+		address = contractAddress
+	}
+
+	return address, nil
+}
+
+// An ImportResolver replaces import placeholders in scripts with the addresses of
+// contracts on a specific network.
+type ImportResolver struct {
+	contracts map[string]Address
+}
+
+// NewImportResolver returns an import resolver for the given network.
+//
+// Placeholders are resolved to the addresses of the well-known contracts of the network,
+// as returned by KnownContractAddresses, and to the addresses in replacements, keyed by
+// contract name. Replacements take precedence over well-known contracts. If the network
+// is not known, only the replacements are used.
+func NewImportResolver(network ChainID, replacements map[string]Address) *ImportResolver {
+	contracts, ok := KnownContractAddresses(network)
+	if !ok {
+		contracts = make(map[string]Address, len(replacements))
+	}
+
+	for name, address := range replacements {
+		contracts[name] = address
+	}
+
+	return &ImportResolver{contracts: contracts}
+}
+
+// Resolve replaces the import placeholders in a script with contract addresses.
+//
+// See ResolveImports for the placeholders that are replaced.
+func (r *ImportResolver) Resolve(script []byte) ([]byte, error) {
+	return ResolveImports(script, r.contracts)
+}
+
+// ResolveImports replaces the import placeholders in the transaction script with the
+// addresses of the imported contracts on the given network.
+//
+// The script is resolved with an ImportResolver for the network and replacements. The
+// script is left unchanged if a placeholder cannot be resolved.
+func (t *Transaction) ResolveImports(network ChainID, replacements map[string]Address) error {
+	script, err := NewImportResolver(network, replacements).Resolve(t.Script)
+	if err != nil {
+		return err
+	}
+
+	t.Script = script
+	return nil
+}
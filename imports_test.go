@@ -0,0 +1,223 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portto/blocto-flow-go-sdk"
+)
+
+func TestTransaction_Imports(t *testing.T) {
+	t.Run("Two imports", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript([]byte(`
+				import FungibleToken from 0xee82856bf20e2aa6
+				import FlowToken from 0xFLOWTOKEN
+
+				transaction {
+					prepare(signer: AuthAccount) {}
+				}
+			`))
+
+		imports, err := tx.Imports()
+		require.NoError(t, err)
+
+		assert.Equal(t, []flow.ImportDecl{
+			{ContractName: "FungibleToken", Location: "0xee82856bf20e2aa6"},
+			{ContractName: "FlowToken", Location: "0xFLOWTOKEN"},
+		}, imports)
+
+		address, ok := imports[0].Address()
+		assert.True(t, ok)
+		assert.Equal(t, flow.HexToAddress("ee82856bf20e2aa6"), address)
+
+		_, ok = imports[1].Address()
+		assert.False(t, ok)
+	})
+
+	t.Run("Multiple contracts and built-ins", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript([]byte(`
+				import Crypto
+				import A, B from 0x01
+				import "./C.cdc"
+				transaction {}
+			`))
+
+		imports, err := tx.Imports()
+		require.NoError(t, err)
+
+		assert.Equal(t, []flow.ImportDecl{
+			{ContractName: "Crypto"},
+			{ContractName: "A", Location: "0x01"},
+			{ContractName: "B", Location: "0x01"},
+			{Location: "./C.cdc"},
+		}, imports)
+	})
+
+	t.Run("Comments and semicolons", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript([]byte(`
+				import FungibleToken from 0xFUNGIBLETOKEN // standard
+				import FlowToken from 0xFLOWTOKEN;
+				import Foo from "./foo//bar.cdc"; /* local */
+				/*
+				import Bar from 0xBAR
+				/* nested */
+				import Baz from 0xBAZ
+				*/
+				// import Qux from 0xQUX
+				/* import Quux from 0xQUUX */ import Corge from 0x01
+
+				transaction {}
+			`))
+
+		imports, err := tx.Imports()
+		require.NoError(t, err)
+
+		assert.Equal(t, []flow.ImportDecl{
+			{ContractName: "FungibleToken", Location: "0xFUNGIBLETOKEN"},
+			{ContractName: "FlowToken", Location: "0xFLOWTOKEN"},
+			{ContractName: "Foo", Location: "./foo//bar.cdc"},
+			{ContractName: "Corge", Location: "0x01"},
+		}, imports)
+	})
+
+	t.Run("No imports", func(t *testing.T) {
+		tx := flow.NewTransaction().SetScript([]byte(`transaction {}`))
+
+		imports, err := tx.Imports()
+		require.NoError(t, err)
+		assert.Empty(t, imports)
+	})
+
+	t.Run("Invalid import", func(t *testing.T) {
+		tx := flow.NewTransaction().SetScript([]byte(`import A from`))
+
+		_, err := tx.Imports()
+		assert.Error(t, err)
+	})
+}
+
+func TestResolveImports(t *testing.T) {
+	contracts := map[string]flow.Address{
+		"FungibleToken": flow.HexToAddress("ee82856bf20e2aa6"),
+	}
+
+	t.Run("Placeholder", func(t *testing.T) {
+		script := []byte("import FungibleToken from 0xFUNGIBLETOKEN\nimport Foo from 0x01\ntransaction {}")
+
+		resolved, err := flow.ResolveImports(script, contracts)
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			"import FungibleToken from 0xee82856bf20e2aa6\nimport Foo from 0x01\ntransaction {}",
+			string(resolved),
+		)
+	})
+
+	t.Run("Trailing comment", func(t *testing.T) {
+		script := []byte("import FungibleToken from 0xFUNGIBLETOKEN; // not 0xFUNGIBLETOKEN\ntransaction {}")
+
+		resolved, err := flow.ResolveImports(script, contracts)
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			"import FungibleToken from 0xee82856bf20e2aa6; // not 0xFUNGIBLETOKEN\ntransaction {}",
+			string(resolved),
+		)
+	})
+
+	t.Run("Commented out", func(t *testing.T) {
+		script := []byte("// import Bar from 0xBAR\n/* import Baz from 0xBAZ */\ntransaction {}")
+
+		resolved, err := flow.ResolveImports(script, contracts)
+		require.NoError(t, err)
+		assert.Equal(t, string(script), string(resolved))
+	})
+
+	t.Run("Unknown contract", func(t *testing.T) {
+		script := []byte("import Bar from 0xBAR")
+
+		_, err := flow.ResolveImports(script, contracts)
+		assert.Error(t, err)
+	})
+}
+
+func TestKnownContractAddresses(t *testing.T) {
+	contracts, ok := flow.KnownContractAddresses(flow.Emulator)
+	require.True(t, ok)
+	assert.Equal(t, flow.ServiceAddress(flow.Emulator), contracts["FlowServiceAccount"])
+
+	_, ok = flow.KnownContractAddresses(flow.ChainID("flow-unknown"))
+	assert.False(t, ok)
+}
+
+func TestTransaction_ResolveImports(t *testing.T) {
+	t.Run("Single import", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript([]byte("import FungibleToken from 0xFUNGIBLETOKEN\ntransaction {}"))
+
+		err := tx.ResolveImports(flow.Testnet, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "import FungibleToken from 0x9a0766d93b6608b7\ntransaction {}", string(tx.Script))
+	})
+
+	t.Run("Multiple imports", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript([]byte("import FungibleToken from 0xFUNGIBLETOKEN\nimport Foo from 0xFOO\ntransaction {}"))
+
+		err := tx.ResolveImports(flow.Mainnet, map[string]flow.Address{
+			"Foo": flow.HexToAddress("01"),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			"import FungibleToken from 0xf233dcee88fe0abe\nimport Foo from 0x0000000000000001\ntransaction {}",
+			string(tx.Script),
+		)
+	})
+
+	t.Run("Trailing comment", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript([]byte("import FungibleToken from 0xFUNGIBLETOKEN // standard\ntransaction {}"))
+
+		err := tx.ResolveImports(flow.Testnet, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "import FungibleToken from 0x9a0766d93b6608b7 // standard\ntransaction {}", string(tx.Script))
+	})
+
+	t.Run("Unknown placeholder", func(t *testing.T) {
+		script := []byte("import Bar from 0xBAR\ntransaction {}")
+		tx := flow.NewTransaction().SetScript(script)
+
+		err := tx.ResolveImports(flow.Emulator, nil)
+		assert.Error(t, err)
+		assert.Equal(t, script, tx.Script)
+	})
+}
@@ -0,0 +1,359 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+)
+
+type transactionJSON struct {
+	Script             []byte                     `json:"script"`
+	Arguments          []json.RawMessage          `json:"arguments"`
+	ReferenceBlockID   string                     `json:"referenceBlockId"`
+	GasLimit           uint64                     `json:"gasLimit"`
+	ProposalKey        proposalKeyJSON            `json:"proposalKey"`
+	Payer              string                     `json:"payer"`
+	Authorizers        []string                   `json:"authorizers"`
+	PayloadSignatures  []transactionSignatureJSON `json:"payloadSignatures"`
+	EnvelopeSignatures []transactionSignatureJSON `json:"envelopeSignatures"`
+}
+
+type proposalKeyJSON struct {
+	Address        string `json:"address"`
+	KeyIndex       int    `json:"keyIndex"`
+	SequenceNumber uint64 `json:"sequenceNumber"`
+}
+
+type transactionSignatureJSON struct {
+	Address     string `json:"address"`
+	SignerIndex int    `json:"signerIndex"`
+	KeyIndex    int    `json:"keyIndex"`
+	Signature   []byte `json:"signature"`
+}
+
+// MarshalJSON returns the JSON representation of this transaction.
+//
+// The script and signatures are encoded as base64 strings and addresses are encoded as
+// 0x-prefixed hex strings. Arguments encoded by AddArgument are included as JSON-CDC
+// objects; any other argument is encoded as a base64 string, so that the arguments and
+// the ID of the transaction are preserved by a JSON round trip.
+func (t Transaction) MarshalJSON() ([]byte, error) {
+	arguments := make([]json.RawMessage, len(t.Arguments))
+	for i, arg := range t.Arguments {
+		b, err := argumentJSON(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode argument at index %d: %w", i, err)
+		}
+
+		arguments[i] = b
+	}
+
+	authorizers := make([]string, len(t.Authorizers))
+	for i, authorizer := range t.Authorizers {
+		authorizers[i] = addressJSON(authorizer)
+	}
+
+	return json.Marshal(transactionJSON{
+		Script:           t.Script,
+		Arguments:        arguments,
+		ReferenceBlockID: t.ReferenceBlockID.Hex(),
+		GasLimit:         t.GasLimit,
+		ProposalKey: proposalKeyJSON{
+			Address:        addressJSON(t.ProposalKey.Address),
+			KeyIndex:       t.ProposalKey.KeyIndex,
+			SequenceNumber: t.ProposalKey.SequenceNumber,
+		},
+		Payer:              addressJSON(t.Payer),
+		Authorizers:        authorizers,
+		PayloadSignatures:  signaturesJSON(t.PayloadSignatures),
+		EnvelopeSignatures: signaturesJSON(t.EnvelopeSignatures),
+	})
+}
+
+// UnmarshalJSON decodes a transaction from the JSON representation returned by MarshalJSON.
+//
+// Arguments are restored byte for byte, so the decoded transaction has the same ID as the
+// encoded one. The reference block ID must be a hex string of exactly 32 bytes.
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	var temp transactionJSON
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	referenceBlockID, err := hex.DecodeString(temp.ReferenceBlockID)
+	if err != nil {
+		return fmt.Errorf("invalid reference block ID: %w", err)
+	}
+
+	if len(referenceBlockID) != len(Identifier{}) {
+		return fmt.Errorf(
+			"invalid reference block ID: expected %d bytes, got %d",
+			len(Identifier{}),
+			len(referenceBlockID),
+		)
+	}
+
+	proposalKeyAddress, err := parseAddressJSON(temp.ProposalKey.Address)
+	if err != nil {
+		return fmt.Errorf("invalid proposal key address: %w", err)
+	}
+
+	payer, err := parseAddressJSON(temp.Payer)
+	if err != nil {
+		return fmt.Errorf("invalid payer address: %w", err)
+	}
+
+	authorizers := make([]Address, len(temp.Authorizers))
+	for i, authorizer := range temp.Authorizers {
+		authorizers[i], err = parseAddressJSON(authorizer)
+		if err != nil {
+			return fmt.Errorf("invalid authorizer address at index %d: %w", i, err)
+		}
+	}
+
+	payloadSignatures, err := parseSignaturesJSON(temp.PayloadSignatures)
+	if err != nil {
+		return fmt.Errorf("invalid payload signature: %w", err)
+	}
+
+	envelopeSignatures, err := parseSignaturesJSON(temp.EnvelopeSignatures)
+	if err != nil {
+		return fmt.Errorf("invalid envelope signature: %w", err)
+	}
+
+	arguments := make([][]byte, len(temp.Arguments))
+	for i, arg := range temp.Arguments {
+		arguments[i], err = parseArgumentJSON(arg)
+		if err != nil {
+			return fmt.Errorf("invalid argument at index %d: %w", i, err)
+		}
+	}
+
+	*t = Transaction{
+		Script:           temp.Script,
+		Arguments:        arguments,
+		ReferenceBlockID: BytesToID(referenceBlockID),
+		GasLimit:         temp.GasLimit,
+		ProposalKey: ProposalKey{
+			Address:        proposalKeyAddress,
+			KeyIndex:       temp.ProposalKey.KeyIndex,
+			SequenceNumber: temp.ProposalKey.SequenceNumber,
+		},
+		Payer:              payer,
+		Authorizers:        authorizers,
+		PayloadSignatures:  payloadSignatures,
+		EnvelopeSignatures: envelopeSignatures,
+	}
+
+	return nil
+}
+
+// argumentJSON returns the JSON representation of a transaction argument.
+//
+// An argument in the form produced by the JSON-CDC encoder, a JSON object followed by a
+// newline, is embedded as an object if encoding/json would emit the object unchanged,
+// i.e. it is compact and contains no characters that are escaped for HTML. Any other
+// argument is encoded as a base64 string. JSON-CDC values are always objects, so the two
+// forms cannot be confused when decoding.
+func argumentJSON(arg []byte) (json.RawMessage, error) {
+	object := bytes.TrimSuffix(arg, []byte("\n"))
+
+	if len(object) == len(arg)-1 && len(object) > 0 && object[0] == '{' && json.Valid(object) {
+		b, err := json.Marshal(json.RawMessage(object))
+		if err == nil && bytes.Equal(b, object) {
+			return object, nil
+		}
+	}
+
+	return json.Marshal(arg)
+}
+
+// parseArgumentJSON decodes a transaction argument encoded by argumentJSON.
+func parseArgumentJSON(b json.RawMessage) ([]byte, error) {
+	b = bytes.TrimSpace(b)
+
+	if len(b) > 0 && b[0] == '"' {
+		var arg []byte
+		if err := json.Unmarshal(b, &arg); err != nil {
+			return nil, err
+		}
+
+		return arg, nil
+	}
+
+	arg := make([]byte, len(b), len(b)+1)
+	copy(arg, b)
+
+	return append(arg, '\n'), nil
+}
+
+func addressJSON(address Address) string {
+	return address.HexWithPrefix()
+}
+
+// parseAddressJSON parses an address encoded by addressJSON, treating an empty string
+// as the empty address.
+func parseAddressJSON(s string) (Address, error) {
+	if s == "" {
+		return EmptyAddress, nil
+	}
+
+	return ParseAddress(s)
+}
+
+func signaturesJSON(signatures []TransactionSignature) []transactionSignatureJSON {
+	temp := make([]transactionSignatureJSON, len(signatures))
+	for i, sig := range signatures {
+		temp[i] = transactionSignatureJSON{
+			Address:     addressJSON(sig.Address),
+			SignerIndex: sig.SignerIndex,
+			KeyIndex:    sig.KeyIndex,
+			Signature:   sig.Signature,
+		}
+	}
+
+	return temp
+}
+
+func parseSignaturesJSON(temp []transactionSignatureJSON) ([]TransactionSignature, error) {
+	signatures := make([]TransactionSignature, len(temp))
+	for i, sig := range temp {
+		address, err := parseAddressJSON(sig.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address at index %d: %w", i, err)
+		}
+
+		signatures[i] = TransactionSignature{
+			Address:     address,
+			SignerIndex: sig.SignerIndex,
+			KeyIndex:    sig.KeyIndex,
+			Signature:   sig.Signature,
+		}
+	}
+
+	return signatures, nil
+}
+
+type transactionResultJSON struct {
+	Status string      `json:"status"`
+	Error  *string     `json:"error"`
+	Events []eventJSON `json:"events"`
+}
+
+type eventJSON struct {
+	Type             string          `json:"type"`
+	TransactionID    string          `json:"transactionId"`
+	TransactionIndex int             `json:"transactionIndex"`
+	EventIndex       int             `json:"eventIndex"`
+	Payload          json.RawMessage `json:"payload"`
+}
+
+// MarshalJSON returns the JSON representation of this transaction result.
+//
+// The status is encoded as its string representation and the error as its message, or
+// null if the transaction succeeded. Event payloads are included as JSON-CDC objects.
+func (r TransactionResult) MarshalJSON() ([]byte, error) {
+	var errorMessage *string
+	if r.Error != nil {
+		msg := r.Error.Error()
+		errorMessage = &msg
+	}
+
+	events := make([]eventJSON, len(r.Events))
+	for i, event := range r.Events {
+		payload, err := jsoncdc.Encode(event.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode payload of event at index %d: %w", i, err)
+		}
+
+		events[i] = eventJSON{
+			Type:             event.Type,
+			TransactionID:    event.TransactionID.Hex(),
+			TransactionIndex: event.TransactionIndex,
+			EventIndex:       event.EventIndex,
+			Payload:          payload,
+		}
+	}
+
+	return json.Marshal(transactionResultJSON{
+		Status: r.Status.String(),
+		Error:  errorMessage,
+		Events: events,
+	})
+}
+
+// UnmarshalJSON decodes a transaction result from the JSON representation returned by
+// MarshalJSON.
+func (r *TransactionResult) UnmarshalJSON(data []byte) error {
+	var temp transactionResultJSON
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	status, err := ParseTransactionStatus(temp.Status)
+	if err != nil {
+		return err
+	}
+
+	var resultErr error
+	if temp.Error != nil {
+		resultErr = errors.New(*temp.Error)
+	}
+
+	events := make([]Event, len(temp.Events))
+	for i, event := range temp.Events {
+		txID, err := hex.DecodeString(event.TransactionID)
+		if err != nil {
+			return fmt.Errorf("invalid transaction ID of event at index %d: %w", i, err)
+		}
+
+		value, err := jsoncdc.Decode(event.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to decode payload of event at index %d: %w", i, err)
+		}
+
+		eventValue, ok := value.(cadence.Event)
+		if !ok {
+			return fmt.Errorf("payload of event at index %d is not an event", i)
+		}
+
+		events[i] = Event{
+			Type:             event.Type,
+			TransactionID:    BytesToID(txID),
+			TransactionIndex: event.TransactionIndex,
+			EventIndex:       event.EventIndex,
+			Value:            eventValue,
+		}
+	}
+
+	*r = TransactionResult{
+		Status: status,
+		Error:  resultErr,
+		Events: events,
+	}
+
+	return nil
+}
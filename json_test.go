@@ -0,0 +1,184 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portto/blocto-flow-go-sdk"
+	"github.com/portto/blocto-flow-go-sdk/test"
+)
+
+func TestTransaction_JSON(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		tx := test.TransactionGenerator().New()
+
+		err := tx.AddArgument(cadence.NewString("foo"))
+		require.NoError(t, err)
+
+		b, err := json.Marshal(tx)
+		require.NoError(t, err)
+
+		var decoded flow.Transaction
+		err = json.Unmarshal(b, &decoded)
+		require.NoError(t, err)
+
+		assert.Equal(t, tx.ID(), decoded.ID())
+		assert.Equal(t, tx.Encode(), decoded.Encode())
+		assert.Equal(t, tx.Authorizers, decoded.Authorizers)
+		assert.Equal(t, tx.PayloadSignatures, decoded.PayloadSignatures)
+		assert.Equal(t, tx.EnvelopeSignatures, decoded.EnvelopeSignatures)
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript([]byte("transaction {}")).
+			SetPayer(flow.HexToAddress("01"))
+
+		err := tx.AddArgument(cadence.NewInt(42))
+		require.NoError(t, err)
+
+		b, err := json.Marshal(tx)
+		require.NoError(t, err)
+
+		var fields map[string]interface{}
+		err = json.Unmarshal(b, &fields)
+		require.NoError(t, err)
+
+		assert.Equal(t, "dHJhbnNhY3Rpb24ge30=", fields["script"])
+		assert.Equal(t, "0x0000000000000001", fields["payer"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"type": "Int", "value": "42"},
+		}, fields["arguments"])
+	})
+
+	t.Run("Raw arguments", func(t *testing.T) {
+		arguments := [][]byte{
+			[]byte(`{"type": "Int", "value": "1"}`),
+			[]byte(`{"type":"Int","value":"1"}`),
+			[]byte(`{"type":"String","value":"<a & b>"}` + "\n"),
+			[]byte(`{"type":"Int","value":"2"}` + "\n"),
+			[]byte("not json"),
+		}
+
+		tx := test.TransactionGenerator().New()
+		for _, arg := range arguments {
+			tx.AddRawArgument(arg)
+		}
+
+		b, err := json.Marshal(tx)
+		require.NoError(t, err)
+
+		var decoded flow.Transaction
+		err = json.Unmarshal(b, &decoded)
+		require.NoError(t, err)
+
+		assert.Equal(t, tx.ID(), decoded.ID())
+		require.Len(t, decoded.Arguments, len(tx.Arguments))
+
+		for i, arg := range tx.Arguments {
+			assert.Equal(t, string(arg), string(decoded.Arguments[i]))
+		}
+	})
+
+	t.Run("Invalid reference block ID", func(t *testing.T) {
+		for _, id := range []string{"", "01", strings.Repeat("01", 33)} {
+			var tx flow.Transaction
+			err := json.Unmarshal([]byte(`{"referenceBlockId": "`+id+`"}`), &tx)
+			assert.Error(t, err, id)
+		}
+	})
+
+	t.Run("Invalid address", func(t *testing.T) {
+		var tx flow.Transaction
+		err := json.Unmarshal([]byte(`{"referenceBlockId": "`+flow.EmptyID.Hex()+`", "payer": "0xzz"}`), &tx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "payer")
+	})
+}
+
+func TestTransactionResult_JSON(t *testing.T) {
+	events := test.EventGenerator()
+
+	t.Run("Sealed with events", func(t *testing.T) {
+		result := flow.TransactionResult{
+			Status: flow.TransactionStatusSealed,
+			Events: []flow.Event{events.New(), events.New()},
+		}
+
+		b, err := json.Marshal(result)
+		require.NoError(t, err)
+
+		var fields map[string]interface{}
+		err = json.Unmarshal(b, &fields)
+		require.NoError(t, err)
+
+		assert.Equal(t, "SEALED", fields["status"])
+		assert.Nil(t, fields["error"])
+
+		var decoded flow.TransactionResult
+		err = json.Unmarshal(b, &decoded)
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.TransactionStatusSealed, decoded.Status)
+		assert.NoError(t, decoded.Error)
+		require.Len(t, decoded.Events, 2)
+
+		for i, event := range decoded.Events {
+			expected := result.Events[i]
+
+			assert.Equal(t, expected.Type, event.Type)
+			assert.Equal(t, expected.TransactionID, event.TransactionID)
+			assert.Equal(t, expected.TransactionIndex, event.TransactionIndex)
+			assert.Equal(t, expected.EventIndex, event.EventIndex)
+			assert.Equal(t, expected.Value.Fields, event.Value.Fields)
+		}
+	})
+
+	t.Run("Failed with error", func(t *testing.T) {
+		result := flow.TransactionResult{
+			Status: flow.TransactionStatusSealed,
+			Error:  errors.New("panic: insufficient balance"),
+		}
+
+		b, err := json.Marshal(result)
+		require.NoError(t, err)
+
+		var decoded flow.TransactionResult
+		err = json.Unmarshal(b, &decoded)
+		require.NoError(t, err)
+
+		require.Error(t, decoded.Error)
+		assert.Equal(t, "panic: insufficient balance", decoded.Error.Error())
+		assert.Empty(t, decoded.Events)
+	})
+
+	t.Run("Unknown status", func(t *testing.T) {
+		var decoded flow.TransactionResult
+		err := json.Unmarshal([]byte(`{"status": "FOO"}`), &decoded)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,87 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	prepareRegexp      = regexp.MustCompile(`\bprepare\s*\(`)
+	lineCommentRegexp  = regexp.MustCompile(`//[^\n]*`)
+	blockCommentRegexp = regexp.MustCompile(`(?s)/\*.*?\*/`)
+)
+
+// DeclaredAuthorizerCount returns the number of parameters of the prepare block declared
+// by the transaction script.
+//
+// Each parameter of the prepare block is an authorizing account, so a valid transaction
+// has as many authorizers as declared parameters.
+//
+// This function returns an error if the script does not declare a prepare block.
+func (t *Transaction) DeclaredAuthorizerCount() (int, error) {
+	return declaredAuthorizerCount(t.Script)
+}
+
+func declaredAuthorizerCount(script []byte) (int, error) {
+	source := blockCommentRegexp.ReplaceAllString(string(script), "")
+	source = lineCommentRegexp.ReplaceAllString(source, "")
+
+	loc := prepareRegexp.FindStringIndex(source)
+	if loc == nil {
+		return 0, fmt.Errorf("script does not declare a prepare block")
+	}
+
+	params := source[loc[1]:]
+
+	depth := 0
+	count := 0
+	current := strings.Builder{}
+
+	for _, r := range params {
+		switch r {
+		case '(', '<', '{', '[':
+			depth++
+		case ']', '}', '>':
+			depth--
+		case ')':
+			if depth == 0 {
+				if strings.TrimSpace(current.String()) != "" {
+					count++
+				}
+
+				return count, nil
+			}
+
+			depth--
+		case ',':
+			if depth == 0 {
+				count++
+				current.Reset()
+				continue
+			}
+		}
+
+		current.WriteRune(r)
+	}
+
+	return 0, fmt.Errorf("prepare block parameters are not terminated")
+}
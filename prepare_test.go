@@ -0,0 +1,82 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portto/blocto-flow-go-sdk"
+)
+
+func TestTransaction_DeclaredAuthorizerCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		count  int
+	}{
+		{
+			name: "Zero authorizers",
+			script: `
+				transaction {
+					prepare() {}
+				}
+			`,
+			count: 0,
+		},
+		{
+			name: "One authorizer",
+			script: `
+				transaction(amount: UFix64) {
+					// prepare(a: AuthAccount, b: AuthAccount)
+					prepare(signer: AuthAccount) {}
+				}
+			`,
+			count: 1,
+		},
+		{
+			name: "Two authorizers",
+			script: `
+				transaction {
+					prepare(payer: AuthAccount, recipient: AuthAccount) {}
+				}
+			`,
+			count: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := flow.NewTransaction().SetScript([]byte(tt.script))
+
+			count, err := tx.DeclaredAuthorizerCount()
+			require.NoError(t, err)
+			assert.Equal(t, tt.count, count)
+		})
+	}
+
+	t.Run("No prepare block", func(t *testing.T) {
+		tx := flow.NewTransaction().SetScript([]byte(`transaction { execute {} }`))
+
+		_, err := tx.DeclaredAuthorizerCount()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,132 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"sync"
+)
+
+// A SequenceNumberManager hands out sequence numbers for a single account key.
+//
+// The sequence number reported by an access node only advances once a transaction
+// is executed, so it lags behind while a sender has several unsealed transactions
+// in flight. The manager tracks the numbers it has handed out and reconciles them
+// against the sequence number reported by the network.
+//
+// A SequenceNumberManager is safe for concurrent use.
+type SequenceNumberManager struct {
+	mu        sync.Mutex
+	next      uint64
+	confirmed uint64
+	stalled   bool
+}
+
+// NewSequenceNumberManager returns a manager that starts handing out sequence
+// numbers from the given value.
+func NewSequenceNumberManager(start uint64) *SequenceNumberManager {
+	return &SequenceNumberManager{
+		next:      start,
+		confirmed: start,
+	}
+}
+
+// NewSequenceNumberManagers returns a manager for each of the given accounts, starting
+// from the sequence number of that account.
+func NewSequenceNumberManagers(seqs map[Address]uint64) map[Address]*SequenceNumberManager {
+	managers := make(map[Address]*SequenceNumberManager, len(seqs))
+
+	for address, seq := range seqs {
+		managers[address] = NewSequenceNumberManager(seq)
+	}
+
+	return managers
+}
+
+// Next returns the next sequence number and marks it as pending.
+func (m *SequenceNumberManager) Next() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	seq := m.next
+	m.next++
+
+	return seq
+}
+
+// Pending returns the number of sequence numbers that have been handed out but
+// are not yet confirmed by the network.
+func (m *SequenceNumberManager) Pending() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.next - m.confirmed
+}
+
+// Reconcile updates the manager with the sequence number reported by the network
+// and returns the number of pending sequence numbers that were dropped.
+//
+// If the reported sequence number has not advanced since the previous call while
+// numbers are still pending, the transaction using the reported number is assumed
+// to have been dropped. Every transaction after it can never execute, so the
+// pending numbers are discarded and handed out again starting from the reported
+// sequence number.
+//
+// Reconcile should therefore be called at an interval longer than it takes for
+// a transaction to be sealed or to expire.
+func (m *SequenceNumberManager) Reconcile(nodeSeq uint64) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// the key was used outside of this manager
+	if nodeSeq >= m.next {
+		m.next = nodeSeq
+		m.confirmed = nodeSeq
+		m.stalled = false
+		return 0
+	}
+
+	if nodeSeq > m.confirmed {
+		m.confirmed = nodeSeq
+		m.stalled = false
+		return 0
+	}
+
+	if !m.stalled {
+		m.stalled = true
+		return 0
+	}
+
+	dropped := m.next - nodeSeq
+
+	m.next = nodeSeq
+	m.confirmed = nodeSeq
+	m.stalled = false
+
+	return dropped
+}
+
+// WouldCollide returns true if two transactions use the same proposal key and sequence number.
+//
+// Only one of two colliding transactions can be executed, since executing either one
+// increments the sequence number of the shared proposal key.
+func WouldCollide(a, b *Transaction) bool {
+	return a.ProposalKey.Address == b.ProposalKey.Address &&
+		a.ProposalKey.KeyIndex == b.ProposalKey.KeyIndex &&
+		a.ProposalKey.SequenceNumber == b.ProposalKey.SequenceNumber
+}
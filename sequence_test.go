@@ -0,0 +1,98 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portto/blocto-flow-go-sdk"
+)
+
+func TestSequenceNumberManager(t *testing.T) {
+	t.Run("Pending", func(t *testing.T) {
+		m := flow.NewSequenceNumberManager(5)
+
+		assert.Equal(t, uint64(5), m.Next())
+		assert.Equal(t, uint64(6), m.Next())
+		assert.Equal(t, uint64(7), m.Next())
+		assert.Equal(t, uint64(3), m.Pending())
+
+		assert.Equal(t, uint64(0), m.Reconcile(6))
+		assert.Equal(t, uint64(2), m.Pending())
+
+		assert.Equal(t, uint64(0), m.Reconcile(8))
+		assert.Equal(t, uint64(0), m.Pending())
+	})
+
+	t.Run("External use", func(t *testing.T) {
+		m := flow.NewSequenceNumberManager(5)
+
+		m.Next()
+
+		assert.Equal(t, uint64(0), m.Reconcile(10))
+		assert.Equal(t, uint64(0), m.Pending())
+		assert.Equal(t, uint64(10), m.Next())
+	})
+
+	t.Run("Dropped transaction", func(t *testing.T) {
+		m := flow.NewSequenceNumberManager(5)
+
+		m.Next()
+		m.Next()
+		m.Next()
+
+		// transaction 5 is executed, transaction 6 is dropped
+		assert.Equal(t, uint64(0), m.Reconcile(6))
+		assert.Equal(t, uint64(0), m.Reconcile(6))
+		assert.Equal(t, uint64(2), m.Pending())
+
+		// the sequence number still has not advanced
+		assert.Equal(t, uint64(2), m.Reconcile(6))
+		assert.Equal(t, uint64(0), m.Pending())
+		assert.Equal(t, uint64(6), m.Next())
+	})
+}
+
+func TestWouldCollide(t *testing.T) {
+	address := flow.HexToAddress("01")
+
+	a := flow.NewTransaction().SetProposalKey(address, 1, 42)
+
+	t.Run("Colliding", func(t *testing.T) {
+		b := flow.NewTransaction().
+			SetScript([]byte(`transaction {}`)).
+			SetProposalKey(address, 1, 42)
+
+		assert.True(t, flow.WouldCollide(a, b))
+	})
+
+	t.Run("Different sequence number", func(t *testing.T) {
+		b := flow.NewTransaction().SetProposalKey(address, 1, 43)
+
+		assert.False(t, flow.WouldCollide(a, b))
+	})
+
+	t.Run("Different key", func(t *testing.T) {
+		b := flow.NewTransaction().SetProposalKey(address, 2, 42)
+
+		assert.False(t, flow.WouldCollide(a, b))
+	})
+}
@@ -29,6 +29,10 @@ const domainTagLength = 32
 // TransactionDomainTag is the prefix of all signed transaction payloads.
 //
 // A domain tag is encoded as UTF-8 bytes, right padded to a total length of 32 bytes.
+//
+// The transaction layer owns this tag: SignPayloadMessage and SignEnvelopeMessage prepend it
+// before passing the message to a crypto.Signer, so signers must sign the bytes they are given
+// without adding a tag of their own.
 var TransactionDomainTag = paddedDomainTag("FLOW-V0.0-transaction")
 
 // UserDomainTag is the prefix of all signed user space payloads.
@@ -48,6 +52,59 @@ func paddedDomainTag(s string) [domainTagLength]byte {
 	return tag
 }
 
+// SignPayloadMessage signs a transaction payload message and returns the detached signature.
+//
+// The message must be the canonical payload message of a transaction, as returned by
+// Transaction.PayloadMessage. This allows a signer to produce payload signatures without
+// holding the full transaction.
+//
+// The message is prefixed with TransactionDomainTag before it is signed.
+func SignPayloadMessage(message []byte, signer crypto.Signer) ([]byte, error) {
+	return signer.Sign(transactionDomainMessage(message))
+}
+
+// SignEnvelopeMessage signs a transaction envelope message and returns the detached signature.
+//
+// The message must be the canonical envelope message of a transaction, as returned by
+// Transaction.EnvelopeMessage. This allows a signer to produce envelope signatures without
+// holding the full transaction.
+//
+// The message is prefixed with TransactionDomainTag before it is signed.
+func SignEnvelopeMessage(message []byte, signer crypto.Signer) ([]byte, error) {
+	return signer.Sign(transactionDomainMessage(message))
+}
+
+// VerifyPayloadMessage verifies a detached signature of a transaction payload message.
+//
+// The message is prefixed with TransactionDomainTag before it is hashed, so the signature
+// must have been produced over the domain-tagged message.
+func VerifyPayloadMessage(
+	message []byte,
+	signature []byte,
+	publicKey crypto.PublicKey,
+	hasher crypto.Hasher,
+) (bool, error) {
+	return publicKey.Verify(signature, transactionDomainMessage(message), hasher)
+}
+
+// VerifyEnvelopeMessage verifies a detached signature of a transaction envelope message.
+//
+// The message is prefixed with TransactionDomainTag before it is hashed, so the signature
+// must have been produced over the domain-tagged message.
+func VerifyEnvelopeMessage(
+	message []byte,
+	signature []byte,
+	publicKey crypto.PublicKey,
+	hasher crypto.Hasher,
+) (bool, error) {
+	return publicKey.Verify(signature, transactionDomainMessage(message), hasher)
+}
+
+// transactionDomainMessage returns the message prefixed with the transaction domain tag.
+func transactionDomainMessage(message []byte) []byte {
+	return append(TransactionDomainTag[:], message...)
+}
+
 // SignUserMessage signs a message in the user domain.
 //
 // User messages are distinct from other signed messages (i.e. transactions), and can be
@@ -0,0 +1,229 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portto/blocto-flow-go-sdk"
+	"github.com/portto/blocto-flow-go-sdk/crypto"
+)
+
+// hashSigner is a deterministic signer that "signs" a message by hashing it.
+type hashSigner struct{}
+
+func (hashSigner) Sign(message []byte) ([]byte, error) {
+	return crypto.NewSHA3_256().ComputeHash(message), nil
+}
+
+func TestSignPayloadMessage(t *testing.T) {
+	tx := baseTx()
+
+	sig, err := flow.SignPayloadMessage(tx.PayloadMessage(), hashSigner{})
+	require.NoError(t, err)
+
+	address := flow.HexToAddress("02")
+
+	err = tx.SignPayload(address, 0, hashSigner{})
+	require.NoError(t, err)
+
+	for _, s := range tx.PayloadSignatures {
+		if s.Address == address {
+			assert.Equal(t, s.Signature, sig)
+			return
+		}
+	}
+
+	t.Fatal("payload signature not found")
+}
+
+func TestSignEnvelopeMessage(t *testing.T) {
+	tx := baseTx()
+
+	sig, err := flow.SignEnvelopeMessage(tx.EnvelopeMessage(), hashSigner{})
+	require.NoError(t, err)
+
+	err = tx.SignEnvelope(flow.HexToAddress("01"), 4, hashSigner{})
+	require.NoError(t, err)
+
+	require.Len(t, tx.EnvelopeSignatures, 1)
+	assert.Equal(t, sig, tx.EnvelopeSignatures[0].Signature)
+}
+
+func TestVerifyPayloadMessage(t *testing.T) {
+	tx := baseTx()
+
+	seed := make([]byte, crypto.MinSeedLength)
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seed)
+	require.NoError(t, err)
+
+	signer := crypto.NewInMemorySigner(privateKey, crypto.SHA3_256)
+	hasher := crypto.NewSHA3_256()
+
+	t.Run("Tagged message", func(t *testing.T) {
+		message := append(flow.TransactionDomainTag[:], tx.PayloadMessage()...)
+
+		sig, err := signer.Sign(message)
+		require.NoError(t, err)
+
+		valid, err := flow.VerifyPayloadMessage(tx.PayloadMessage(), sig, privateKey.PublicKey(), hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Untagged message", func(t *testing.T) {
+		sig, err := signer.Sign(tx.PayloadMessage())
+		require.NoError(t, err)
+
+		valid, err := flow.VerifyPayloadMessage(tx.PayloadMessage(), sig, privateKey.PublicKey(), hasher)
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+}
+
+func TestVerifyEnvelopeMessage(t *testing.T) {
+	tx := baseTx()
+
+	seed := make([]byte, crypto.MinSeedLength)
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seed)
+	require.NoError(t, err)
+
+	signer := crypto.NewInMemorySigner(privateKey, crypto.SHA3_256)
+	hasher := crypto.NewSHA3_256()
+
+	t.Run("Tagged message", func(t *testing.T) {
+		message := append(flow.TransactionDomainTag[:], tx.EnvelopeMessage()...)
+
+		sig, err := signer.Sign(message)
+		require.NoError(t, err)
+
+		valid, err := flow.VerifyEnvelopeMessage(tx.EnvelopeMessage(), sig, privateKey.PublicKey(), hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Untagged message", func(t *testing.T) {
+		sig, err := signer.Sign(tx.EnvelopeMessage())
+		require.NoError(t, err)
+
+		valid, err := flow.VerifyEnvelopeMessage(tx.EnvelopeMessage(), sig, privateKey.PublicKey(), hasher)
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+}
+
+func TestSignEnvelope_DomainTag(t *testing.T) {
+	tx := baseTx()
+
+	seed := make([]byte, crypto.MinSeedLength)
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seed)
+	require.NoError(t, err)
+
+	signer := crypto.NewInMemorySigner(privateKey, crypto.SHA3_256)
+	hasher := crypto.NewSHA3_256()
+
+	err = tx.SignEnvelope(flow.HexToAddress("01"), 4, signer)
+	require.NoError(t, err)
+	require.Len(t, tx.EnvelopeSignatures, 1)
+
+	sig := tx.EnvelopeSignatures[0].Signature
+
+	tagged := append(flow.TransactionDomainTag[:], tx.EnvelopeMessage()...)
+
+	valid, err := privateKey.PublicKey().Verify(sig, tagged, hasher)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = privateKey.PublicKey().Verify(sig, tx.EnvelopeMessage(), hasher)
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestTransaction_VerifySignatures(t *testing.T) {
+	newKey := func(t *testing.T, seedByte byte) (crypto.PrivateKey, flow.AccountKey) {
+		seed := make([]byte, crypto.MinSeedLength)
+		seed[0] = seedByte
+
+		privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seed)
+		require.NoError(t, err)
+
+		return privateKey, flow.AccountKey{
+			Index:     0,
+			PublicKey: privateKey.PublicKey(),
+			SigAlgo:   crypto.ECDSA_P256,
+			HashAlgo:  crypto.SHA3_256,
+			Weight:    flow.AccountKeyWeightThreshold,
+		}
+	}
+
+	authorizerKey, authorizerAccountKey := newKey(t, 1)
+	payerKey, payerAccountKey := newKey(t, 2)
+
+	authorizer := flow.HexToAddress("02")
+	payer := flow.HexToAddress("01")
+
+	keysByAddress := map[flow.Address][]flow.AccountKey{
+		authorizer: {authorizerAccountKey},
+		payer:      {payerAccountKey},
+	}
+
+	newSignedTx := func(t *testing.T) *flow.Transaction {
+		tx := flow.NewTransaction().
+			SetScript([]byte("transaction {}")).
+			SetProposalKey(authorizer, 0, 42).
+			SetPayer(payer).
+			AddAuthorizer(authorizer)
+
+		err := tx.SignPayload(authorizer, 0, crypto.NewInMemorySigner(authorizerKey, crypto.SHA3_256))
+		require.NoError(t, err)
+
+		err = tx.SignEnvelope(payer, 0, crypto.NewInMemorySigner(payerKey, crypto.SHA3_256))
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	t.Run("Valid signatures", func(t *testing.T) {
+		valid, err := newSignedTx(t).VerifySignatures(keysByAddress)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Tampered payload", func(t *testing.T) {
+		tx := newSignedTx(t)
+		tx.SetGasLimit(tx.GasLimit + 1)
+
+		valid, err := tx.VerifySignatures(keysByAddress)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "payload signature 0")
+		assert.False(t, valid)
+	})
+
+	t.Run("Missing key", func(t *testing.T) {
+		valid, err := newSignedTx(t).VerifySignatures(map[flow.Address][]flow.AccountKey{
+			authorizer: {authorizerAccountKey},
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "envelope signature 0")
+		assert.False(t, valid)
+	})
+}
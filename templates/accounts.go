@@ -19,6 +19,8 @@
 package templates
 
 import (
+	"fmt"
+
 	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 
@@ -153,6 +155,20 @@ func RemoveAccountKey(address flow.Address, keyIndex int) *flow.Transaction {
 		AddAuthorizer(address)
 }
 
+// RevokeAccountKey generates a transaction that revokes a key on an account.
+//
+// The Cadence runtime supported by this SDK has no separate revocation operation, so the
+// key is revoked by removing it from the account, using the same script as RemoveAccountKey.
+//
+// This function returns an error if the key index is negative.
+func RevokeAccountKey(address flow.Address, keyIndex int) (*flow.Transaction, error) {
+	if keyIndex < 0 {
+		return nil, fmt.Errorf("invalid key index %d: must not be negative", keyIndex)
+	}
+
+	return RemoveAccountKey(address, keyIndex), nil
+}
+
 const replaceAccountKeysTemplate = `
 transaction(publicKeys: [[UInt8]], keyIDs: [Int]) {
   prepare(signer: AuthAccount) {
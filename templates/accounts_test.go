@@ -0,0 +1,55 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portto/blocto-flow-go-sdk"
+	"github.com/portto/blocto-flow-go-sdk/templates"
+)
+
+func TestRevokeAccountKey(t *testing.T) {
+	address := flow.HexToAddress("01")
+
+	t.Run("Valid key index", func(t *testing.T) {
+		tx, err := templates.RevokeAccountKey(address, 2)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(tx.Script), "signer.removePublicKey(keyIndex)")
+		assert.Equal(t, []flow.Address{address}, tx.Authorizers)
+
+		require.Len(t, tx.Arguments, 1)
+
+		arg, err := jsoncdc.Decode(tx.Arguments[0])
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(2), arg)
+	})
+
+	t.Run("Negative key index", func(t *testing.T) {
+		tx, err := templates.RevokeAccountKey(address, -1)
+		assert.Error(t, err)
+		assert.Nil(t, tx)
+	})
+}
@@ -200,6 +200,7 @@ func CollectionGuaranteeGenerator() *CollectionGuarantees {
 func (g *CollectionGuarantees) New() *flow.CollectionGuarantee {
 	return &flow.CollectionGuarantee{
 		CollectionID: g.ids.New(),
+		Signatures:   [][]byte{[]byte("signature")},
 	}
 }
 
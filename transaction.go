@@ -19,8 +19,15 @@
 package flow
 
 import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
@@ -82,18 +89,172 @@ type Transaction struct {
 	//
 	// You can find more information about transaction signatures here: https://docs.onflow.org/concepts/transaction-signing/#anatomy-of-a-transaction
 	EnvelopeSignatures []TransactionSignature
+
+	// maxArguments overrides MaxArguments for this transaction if non-zero.
+	maxArguments int
+
+	// maxBytes limits the size of the encoded transaction if non-zero.
+	maxBytes int
+
+	// argumentCache holds decoded arguments if argument caching is enabled.
+	argumentCache *argumentCache
+
+	// signedEnvelopeMessage is the envelope message at the time the first envelope
+	// signature was added.
+	signedEnvelopeMessage []byte
+
+	// envelopeMessageChanged is true if envelope signatures were added over different
+	// envelope messages.
+	envelopeMessageChanged bool
 }
 
-// NewTransaction initializes and returns an empty transaction.
+// MaxArguments is the default maximum number of arguments that can be added to a transaction.
+//
+// The limit can be overridden for an individual transaction with SetMaxArguments.
+const MaxArguments = 1000
+
+// DefaultGasLimit is the gas limit of a transaction created with NewTransaction.
+//
+// The default can be overridden with SetGasLimit. A gas limit of zero is still possible
+// by calling SetGasLimit(0) after construction, although such a transaction fails at execution.
+const DefaultGasLimit uint64 = 1000
+
+// DefaultTransactionExpiry is the number of blocks after its reference block for which a
+// transaction can be included in a block.
+const DefaultTransactionExpiry = 600
+
+// NewTransaction initializes and returns an empty transaction with the default gas limit.
 func NewTransaction() *Transaction {
-	return &Transaction{}
+	return &Transaction{
+		GasLimit: DefaultGasLimit,
+	}
+}
+
+// Copy returns a deep copy of this transaction.
+//
+// The script, arguments, authorizers and signatures of the copy do not share memory with
+// the original, so either transaction can be modified without affecting the other. If
+// argument caching is enabled, the copy starts with an empty cache.
+func (t *Transaction) Copy() *Transaction {
+	c := &Transaction{
+		Script:           copyBytes(t.Script),
+		ReferenceBlockID: t.ReferenceBlockID,
+		GasLimit:         t.GasLimit,
+		ProposalKey:      t.ProposalKey,
+		Payer:            t.Payer,
+		maxArguments:     t.maxArguments,
+		maxBytes:         t.maxBytes,
+
+		signedEnvelopeMessage:  copyBytes(t.signedEnvelopeMessage),
+		envelopeMessageChanged: t.envelopeMessageChanged,
+	}
+
+	if t.Arguments != nil {
+		c.Arguments = make([][]byte, len(t.Arguments))
+		for i, arg := range t.Arguments {
+			c.Arguments[i] = copyBytes(arg)
+		}
+	}
+
+	if t.Authorizers != nil {
+		c.Authorizers = make([]Address, len(t.Authorizers))
+		copy(c.Authorizers, t.Authorizers)
+	}
+
+	c.PayloadSignatures = copySignatures(t.PayloadSignatures)
+	c.EnvelopeSignatures = copySignatures(t.EnvelopeSignatures)
+
+	if t.argumentCache != nil {
+		c.argumentCache = newArgumentCache()
+	}
+
+	return c
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	c := make([]byte, len(b))
+	copy(c, b)
+
+	return c
+}
+
+func copySignatures(sigs []TransactionSignature) []TransactionSignature {
+	if sigs == nil {
+		return nil
+	}
+
+	c := make([]TransactionSignature, len(sigs))
+	for i, sig := range sigs {
+		c[i] = sig
+		c[i].Signature = copyBytes(sig.Signature)
+	}
+
+	return c
 }
 
 // ID returns the canonical SHA3-256 hash of this transaction.
 func (t *Transaction) ID() Identifier {
+	if hook := loadEncodingMetricsHook(); hook != nil {
+		start := time.Now()
+		defer func() { hook(EncodingOpID, time.Since(start)) }()
+	}
+
 	return HashToID(defaultEntityHasher.ComputeHash(t.Encode()))
 }
 
+// Fingerprint returns a hash of the logical contents of this transaction, which can be used
+// to deduplicate transactions.
+//
+// The fingerprint covers the script, arguments, proposal key, payer and authorizers. Unlike
+// the ID, it does not cover the reference block, gas limit or signatures, so a transaction
+// that is retried with a fresh reference block has the same fingerprint as the original.
+//
+// As a tradeoff, two transactions that are intentionally submitted with identical contents
+// are treated as duplicates. The proposal key sequence number is included, so transactions
+// using different sequence numbers always have different fingerprints.
+func (t *Transaction) Fingerprint() Identifier {
+	return HashToID(defaultEntityHasher.ComputeHash(mustRLPEncode(t.fingerprintCanonicalForm())))
+}
+
+// IdempotencyKey returns a key that identifies a logical transaction across submission retries.
+//
+// The key is derived from the fingerprint and the proposal key sequence number, so it does not
+// change when a transaction is signed, re-signed or given a fresh reference block. Services
+// that retry submissions can store the key in a dedupe store when a transaction is first sent
+// and skip any later submission whose key is already present.
+func (t *Transaction) IdempotencyKey() string {
+	return fmt.Sprintf("%s-%d", t.Fingerprint().Hex(), t.ProposalKey.SequenceNumber)
+}
+
+func (t *Transaction) fingerprintCanonicalForm() interface{} {
+	authorizers := make([][]byte, len(t.Authorizers))
+	for i, auth := range t.Authorizers {
+		authorizers[i] = auth.Bytes()
+	}
+
+	return struct {
+		Script                    []byte
+		Arguments                 [][]byte
+		ProposalKeyAddress        []byte
+		ProposalKeyIndex          uint64
+		ProposalKeySequenceNumber uint64
+		Payer                     []byte
+		Authorizers               [][]byte
+	}{
+		Script:                    t.Script,
+		Arguments:                 t.Arguments,
+		ProposalKeyAddress:        t.ProposalKey.Address.Bytes(),
+		ProposalKeyIndex:          uint64(t.ProposalKey.KeyIndex),
+		ProposalKeySequenceNumber: t.ProposalKey.SequenceNumber,
+		Payer:                     t.Payer.Bytes(),
+		Authorizers:               authorizers,
+	}
+}
+
 // SetScript sets the Cadence script for this transaction.
 //
 // The script is the UTF-8 encoded Cadence source code.
@@ -102,24 +263,178 @@ func (t *Transaction) SetScript(script []byte) *Transaction {
 	return t
 }
 
+// SetScriptChecked sets the Cadence script for this transaction.
+//
+// This function returns an error, and leaves the script unchanged, if the encoded
+// transaction would exceed the size limit set with SetMaxBytes.
+func (t *Transaction) SetScriptChecked(script []byte) error {
+	previous := t.Script
+	t.Script = script
+
+	err := t.checkSizeLimit()
+	if err != nil {
+		t.Script = previous
+		return err
+	}
+
+	return nil
+}
+
+// SetMaxBytes limits the size in bytes of the encoded transaction.
+//
+// Once set, AddArgument, AddRawArgumentChecked and SetScriptChecked return an error if
+// the change would cause the encoded transaction to exceed the limit. A limit of zero
+// removes the limit.
+func (t *Transaction) SetMaxBytes(limit int) *Transaction {
+	t.maxBytes = limit
+	return t
+}
+
+func (t *Transaction) checkSizeLimit() error {
+	if t.maxBytes == 0 {
+		return nil
+	}
+
+	size := len(t.Encode())
+	if size > t.maxBytes {
+		return fmt.Errorf("encoded transaction size %d exceeds the limit of %d bytes", size, t.maxBytes)
+	}
+
+	return nil
+}
+
+// SetMaxArguments overrides the maximum number of arguments that can be added to this transaction.
+//
+// A limit of zero restores the default limit defined by MaxArguments.
+func (t *Transaction) SetMaxArguments(limit int) *Transaction {
+	t.maxArguments = limit
+	return t
+}
+
+func (t *Transaction) argumentLimit() int {
+	if t.maxArguments == 0 {
+		return MaxArguments
+	}
+
+	return t.maxArguments
+}
+
+func (t *Transaction) checkArgumentLimit() error {
+	limit := t.argumentLimit()
+
+	if len(t.Arguments) >= limit {
+		return fmt.Errorf("transaction cannot have more than %d arguments", limit)
+	}
+
+	return nil
+}
+
 // AddArgument adds a Cadence argument to this transaction.
+//
+// This function returns an error if the argument cannot be encoded, if the
+// transaction already holds the maximum number of arguments, or if the encoded
+// transaction would exceed the size limit set with SetMaxBytes.
 func (t *Transaction) AddArgument(arg cadence.Value) error {
+	err := t.checkArgumentLimit()
+	if err != nil {
+		return err
+	}
+
 	encodedArg, err := jsoncdc.Encode(arg)
 	if err != nil {
 		return fmt.Errorf("failed to encode argument: %w", err)
 	}
 
-	t.Arguments = append(t.Arguments, encodedArg)
+	return t.appendArgument(encodedArg)
+}
+
+// SetArguments replaces the arguments of this transaction with the given Cadence values.
+//
+// This function returns an error, and leaves the existing arguments unchanged, if an
+// argument cannot be encoded, if there are more arguments than the argument limit, or if
+// the encoded transaction would exceed the size limit set with SetMaxBytes.
+func (t *Transaction) SetArguments(args []cadence.Value) error {
+	limit := t.argumentLimit()
+	if len(args) > limit {
+		return fmt.Errorf("transaction cannot have more than %d arguments", limit)
+	}
+
+	encodedArgs := make([][]byte, len(args))
+
+	for i, arg := range args {
+		encodedArg, err := jsoncdc.Encode(arg)
+		if err != nil {
+			return fmt.Errorf("failed to encode argument at index %d: %w", i, err)
+		}
+
+		encodedArgs[i] = encodedArg
+	}
+
+	previous := t.Arguments
+	t.Arguments = encodedArgs
+
+	err := t.checkSizeLimit()
+	if err != nil {
+		t.Arguments = previous
+		return err
+	}
+
+	return nil
+}
+
+// appendArgument appends an encoded argument unless it would exceed the size limit.
+func (t *Transaction) appendArgument(arg []byte) error {
+	t.Arguments = append(t.Arguments, arg)
+
+	err := t.checkSizeLimit()
+	if err != nil {
+		t.Arguments = t.Arguments[:len(t.Arguments)-1]
+		return err
+	}
+
 	return nil
 }
 
+// AddArrayArgument adds a Cadence array argument containing the given values to this transaction.
+//
+// This function returns an error if the argument cannot be added with AddArgument.
+func (t *Transaction) AddArrayArgument(values []cadence.Value) error {
+	return t.AddArgument(cadence.NewArray(values))
+}
+
+// AddDictionaryArgument adds a Cadence dictionary argument containing the given key-value
+// pairs to this transaction.
+//
+// This function returns an error if the argument cannot be added with AddArgument.
+func (t *Transaction) AddDictionaryArgument(pairs []cadence.KeyValuePair) error {
+	return t.AddArgument(cadence.NewDictionary(pairs))
+}
+
 // AddRawArgument adds a raw JSON-CDC encoded argument to this transaction.
+//
+// This function does not enforce the argument or size limits; use AddRawArgumentChecked
+// when adding arguments from untrusted input.
 func (t *Transaction) AddRawArgument(arg []byte) *Transaction {
 	t.Arguments = append(t.Arguments, arg)
 	return t
 }
 
+// AddRawArgumentChecked adds a raw JSON-CDC encoded argument to this transaction.
+//
+// This function returns an error if the transaction already holds the maximum number of
+// arguments, or if the encoded transaction would exceed the size limit set with SetMaxBytes.
+func (t *Transaction) AddRawArgumentChecked(arg []byte) error {
+	err := t.checkArgumentLimit()
+	if err != nil {
+		return err
+	}
+
+	return t.appendArgument(arg)
+}
+
 // Argument returns the decoded argument at the given index.
+//
+// If argument caching is enabled, the argument is only decoded on first access.
 func (t *Transaction) Argument(i int) (cadence.Value, error) {
 	if i < 0 {
 		return nil, fmt.Errorf("argument index must be positive")
@@ -131,14 +446,139 @@ func (t *Transaction) Argument(i int) (cadence.Value, error) {
 
 	encodedArg := t.Arguments[i]
 
-	arg, err := jsoncdc.Decode(encodedArg)
+	if t.argumentCache != nil {
+		if arg, ok := t.argumentCache.get(i, encodedArg); ok {
+			return arg, nil
+		}
+	}
+
+	arg, err := decodeArgument(encodedArg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode argument at index %d: %w", i, err)
 	}
 
+	if t.argumentCache != nil {
+		t.argumentCache.set(i, encodedArg, arg)
+	}
+
 	return arg, nil
 }
 
+// ArgumentCount returns the number of arguments of this transaction.
+func (t *Transaction) ArgumentCount() int {
+	return len(t.Arguments)
+}
+
+// DecodedArguments returns all arguments of this transaction decoded as Cadence values.
+//
+// This function returns the first decoding error, which identifies the index of the
+// argument that could not be decoded.
+func (t *Transaction) DecodedArguments() ([]cadence.Value, error) {
+	args := make([]cadence.Value, len(t.Arguments))
+
+	for i := range t.Arguments {
+		arg, err := t.Argument(i)
+		if err != nil {
+			return nil, err
+		}
+
+		args[i] = arg
+	}
+
+	return args, nil
+}
+
+// ArgumentsJSON returns the JSON-CDC encoding of each argument, formatted for human reading.
+//
+// If indent is true, each argument is indented with two spaces per level; otherwise it is
+// returned in compact form.
+//
+// This function returns an error if an argument is not valid JSON.
+func (t *Transaction) ArgumentsJSON(indent bool) ([]string, error) {
+	args := make([]string, len(t.Arguments))
+
+	for i, arg := range t.Arguments {
+		var buf bytes.Buffer
+
+		var err error
+		if indent {
+			err = json.Indent(&buf, arg, "", "  ")
+		} else {
+			err = json.Compact(&buf, arg)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to format argument at index %d: %w", i, err)
+		}
+
+		args[i] = buf.String()
+	}
+
+	return args, nil
+}
+
+// SetArgumentCaching enables or disables caching of decoded arguments.
+//
+// When enabled, each argument is decoded on first access with Argument and the
+// decoded value is reused by later calls. A cached value is discarded if the
+// encoded argument changes.
+//
+// Caching is useful when inspecting a few arguments of many transactions, for
+// example when transactions are fetched in bulk.
+func (t *Transaction) SetArgumentCaching(enabled bool) *Transaction {
+	if enabled {
+		if t.argumentCache == nil {
+			t.argumentCache = newArgumentCache()
+		}
+	} else {
+		t.argumentCache = nil
+	}
+
+	return t
+}
+
+// decodeArgument decodes a JSON-CDC encoded argument.
+var decodeArgument = jsoncdc.Decode
+
+// argumentCache is a thread-safe cache of decoded arguments, keyed by argument index.
+type argumentCache struct {
+	mut     sync.Mutex
+	entries map[int]cachedArgument
+}
+
+// cachedArgument is a decoded argument along with the encoded bytes it was decoded from.
+type cachedArgument struct {
+	encoded []byte
+	value   cadence.Value
+}
+
+func newArgumentCache() *argumentCache {
+	return &argumentCache{
+		entries: make(map[int]cachedArgument),
+	}
+}
+
+func (c *argumentCache) get(i int, encoded []byte) (cadence.Value, bool) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	entry, ok := c.entries[i]
+	if !ok || !bytes.Equal(entry.encoded, encoded) {
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+func (c *argumentCache) set(i int, encoded []byte, value cadence.Value) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.entries[i] = cachedArgument{
+		encoded: append([]byte(nil), encoded...),
+		value:   value,
+	}
+}
+
 // SetReferenceBlockID sets the reference block ID for this transaction.
 //
 // A transaction is considered expired if it is submitted to Flow after refBlock + N, where N
@@ -151,12 +591,65 @@ func (t *Transaction) SetReferenceBlockID(blockID Identifier) *Transaction {
 	return t
 }
 
+// SetReferenceBlockIDFromBytes sets the reference block ID for this transaction from its
+// raw bytes.
+//
+// This function returns an error, and leaves the reference block ID unchanged, if the
+// input is not exactly the length of an identifier.
+func (t *Transaction) SetReferenceBlockIDFromBytes(b []byte) (*Transaction, error) {
+	var blockID Identifier
+
+	if len(b) != len(blockID) {
+		return t, fmt.Errorf("reference block ID must be %d bytes, got %d", len(blockID), len(b))
+	}
+
+	copy(blockID[:], b)
+
+	return t.SetReferenceBlockID(blockID), nil
+}
+
 // SetGasLimit sets the gas limit for this transaction.
 func (t *Transaction) SetGasLimit(limit uint64) *Transaction {
 	t.GasLimit = limit
 	return t
 }
 
+// ExceedsMaxGas returns true if the gas limit of this transaction is greater than the given maximum.
+//
+// A transaction that exceeds the maximum gas limit of a network is always rejected.
+func (t *Transaction) ExceedsMaxGas(max uint64) bool {
+	return t.GasLimit > max
+}
+
+// Parameters of the heuristic used by GasLimitWarning.
+const (
+	gasHeuristicBase            = 10
+	gasHeuristicScriptBytes     = 50
+	gasHeuristicPerArgument     = 5
+	gasHeuristicWarningFraction = 2
+)
+
+// GasLimitWarning reports whether the gas limit of this transaction is far below an estimate
+// based on its script length and argument count, and returns the estimate as a suggestion.
+//
+// The estimate is a rough heuristic: it allows one unit of gas per 50 bytes of script and five
+// units per argument, on top of a base of ten, capped at MaxGasLimit. A warning is raised if the
+// gas limit is less than half of the estimate. The result is advisory only; the gas actually
+// used by a transaction depends on what it executes, not on its size.
+func (t *Transaction) GasLimitWarning() (warn bool, suggested uint64) {
+	suggested = gasHeuristicBase +
+		uint64(len(t.Script))/gasHeuristicScriptBytes +
+		uint64(len(t.Arguments))*gasHeuristicPerArgument
+
+	if suggested > MaxGasLimit {
+		suggested = MaxGasLimit
+	}
+
+	warn = t.GasLimit < suggested/gasHeuristicWarningFraction
+
+	return warn, suggested
+}
+
 // SetProposalKey sets the proposal key and sequence number for this transaction.
 //
 // The first two arguments specify the account key to be used, and the last argument is the sequence
@@ -183,42 +676,245 @@ func (t *Transaction) AddAuthorizer(address Address) *Transaction {
 	return t
 }
 
-// signerList returns a list of unique accounts required to sign this transaction.
-//
-// The list is returned in the following order:
-// 1. PROPOSER
-// 2. PAYER
-// 2. AUTHORIZERS (in insertion order)
-//
-// The only exception to the above ordering is for deduplication; if the same account
-// is used in multiple signing roles, only the first occurrence is included in the list.
-func (t *Transaction) signerList() []Address {
-	signers := make([]Address, 0)
-	seen := make(map[Address]struct{})
+// AddAuthorizerChecked adds an authorizer account to this transaction.
+//
+// This function returns an error if the address is empty.
+func (t *Transaction) AddAuthorizerChecked(address Address) error {
+	if address == EmptyAddress {
+		return fmt.Errorf("authorizer address cannot be empty")
+	}
+
+	t.AddAuthorizer(address)
+	return nil
+}
+
+// RemoveAuthorizer removes all occurrences of an authorizer account from this transaction.
+//
+// This function does nothing if the account is not an authorizer.
+func (t *Transaction) RemoveAuthorizer(address Address) *Transaction {
+	authorizers := make([]Address, 0, len(t.Authorizers))
+
+	for _, authorizer := range t.Authorizers {
+		if authorizer != address {
+			authorizers = append(authorizers, authorizer)
+		}
+	}
+
+	t.Authorizers = authorizers
+	return t
+}
+
+// SetAuthorizers replaces the authorizer accounts of this transaction.
+func (t *Transaction) SetAuthorizers(addresses []Address) *Transaction {
+	t.Authorizers = make([]Address, len(addresses))
+	copy(t.Authorizers, addresses)
+	return t
+}
+
+// Validate returns an error if this transaction is invalid.
+//
+// A transaction is invalid for the following reasons:
+// - Its script is empty
+// - Its reference block ID is not set
+// - Its payer is the empty address
+// - Its proposal key address is the empty address
+// - Its gas limit is zero
+// - One of its authorizers is the empty address
+//
+// The returned error describes the first problem found, in the order listed above.
+func (t *Transaction) Validate() error {
+	if len(t.Script) == 0 {
+		return fmt.Errorf("script cannot be empty")
+	}
+
+	if t.ReferenceBlockID.IsZero() {
+		return fmt.Errorf("reference block ID cannot be empty")
+	}
+
+	if t.Payer == EmptyAddress {
+		return fmt.Errorf("payer cannot be empty")
+	}
+
+	if t.ProposalKey.Address == EmptyAddress {
+		return fmt.Errorf("proposal key address cannot be empty")
+	}
+
+	if t.GasLimit == 0 {
+		return fmt.Errorf("gas limit cannot be zero")
+	}
+
+	for i, authorizer := range t.Authorizers {
+		if authorizer == EmptyAddress {
+			return fmt.Errorf("authorizer at index %d is the empty address", i)
+		}
+	}
+
+	return nil
+}
+
+// SetPayerAsAuthorizer adds the payer account to the list of authorizers for this transaction,
+// unless it is already an authorizer.
+//
+// This should be called after SetPayer, and is appropriate when the payer account also needs to
+// mutate its own on-chain state (e.g. the payer is also the sender of a token transfer). The payer
+// is appended to the end of the authorizer list, so the script must declare a matching
+// AuthAccount parameter in that position.
+//
+// This function has no effect if the payer is not set.
+func (t *Transaction) SetPayerAsAuthorizer() *Transaction {
+	if t.Payer == EmptyAddress {
+		return t
+	}
+
+	for _, authorizer := range t.Authorizers {
+		if authorizer == t.Payer {
+			return t
+		}
+	}
+
+	return t.AddAuthorizer(t.Payer)
+}
+
+// SignerList returns a list of unique accounts required to sign this transaction.
+//
+// The position of an account in this list is the signer index used by its signatures.
+// The list is ordered as proposer, payer and authorizers, with each account included
+// only at its first occurrence.
+func (t *Transaction) SignerList() []Address {
+	return t.signerList()
+}
+
+// signerList returns a list of unique accounts required to sign this transaction.
+//
+// The list is returned in the following order:
+// 1. PROPOSER
+// 2. PAYER
+// 2. AUTHORIZERS (in insertion order)
+//
+// The only exception to the above ordering is for deduplication; if the same account
+// is used in multiple signing roles, only the first occurrence is included in the list.
+func (t *Transaction) signerList() []Address {
+	signers := make([]Address, 0)
+	seen := make(map[Address]struct{})
+
+	var addSigner = func(address Address) {
+		_, ok := seen[address]
+		if ok {
+			return
+		}
+
+		signers = append(signers, address)
+		seen[address] = struct{}{}
+	}
+
+	if t.ProposalKey.Address != EmptyAddress {
+		addSigner(t.ProposalKey.Address)
+	}
+
+	if t.Payer != EmptyAddress {
+		addSigner(t.Payer)
+	}
+
+	for _, authorizer := range t.Authorizers {
+		addSigner(authorizer)
+	}
+
+	return signers
+}
+
+// An AccountGetter fetches accounts by address.
+//
+// This interface is satisfied by the Flow Access API client.
+type AccountGetter interface {
+	GetAccount(ctx context.Context, address Address) (*Account, error)
+}
+
+// RequiredKeysPerAddress returns the minimal set of key indexes that each signer of this
+// transaction must sign with.
+//
+// The payer and each authorizer must sign with keys whose combined weight reaches
+// AccountKeyWeightThreshold; the keys are selected using the key weights of the accounts
+// fetched from the provided client. The proposer must always sign with the proposal key,
+// which is included first in the set for the proposer address.
+//
+// This function returns an error if an account cannot be fetched or if its usable keys
+// cannot meet the required weight.
+func (t *Transaction) RequiredKeysPerAddress(ctx context.Context, client AccountGetter) (map[Address][]int, error) {
+	weighted := make(map[Address]struct{})
+
+	if t.Payer != EmptyAddress {
+		weighted[t.Payer] = struct{}{}
+	}
+
+	for _, authorizer := range t.Authorizers {
+		weighted[authorizer] = struct{}{}
+	}
+
+	keys := make(map[Address][]int)
+
+	for _, address := range t.signerList() {
+		var required []int
+		if address == t.ProposalKey.Address {
+			required = append(required, t.ProposalKey.KeyIndex)
+		}
 
-	var addSigner = func(address Address) {
-		_, ok := seen[address]
-		if ok {
-			return
+		if _, ok := weighted[address]; !ok {
+			keys[address] = required
+			continue
 		}
 
-		signers = append(signers, address)
-		seen[address] = struct{}{}
+		account, err := client.GetAccount(ctx, address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account %s: %w", address, err)
+		}
+
+		indexes, err := account.minimalSigningKeySet(required...)
+		if err != nil {
+			return nil, fmt.Errorf("account %s cannot meet the required key weight: %w", address, err)
+		}
+
+		keys[address] = indexes
 	}
 
-	if t.ProposalKey.Address != EmptyAddress {
-		addSigner(t.ProposalKey.Address)
+	return keys, nil
+}
+
+// SigningProgress returns the number of signatures that have been collected out of
+// the number of signatures required to submit this transaction.
+//
+// Each distinct proposer and authorizer account requires a payload signature, and the
+// payer requires an envelope signature. An account with several roles only requires a
+// single signature, and the payer only signs the envelope, even if it also has other roles.
+//
+// Key weights are not taken into account: an account slot is considered collected once
+// at least one signature from that account is present.
+func (t *Transaction) SigningProgress() (collected, required int) {
+	payloadSigners := make(map[Address]bool)
+	for _, sig := range t.PayloadSignatures {
+		payloadSigners[sig.Address] = true
 	}
 
-	if t.Payer != EmptyAddress {
-		addSigner(t.Payer)
+	envelopeSigners := make(map[Address]bool)
+	for _, sig := range t.EnvelopeSignatures {
+		envelopeSigners[sig.Address] = true
 	}
 
-	for _, authorizer := range t.Authorizers {
-		addSigner(authorizer)
+	for _, signer := range t.signerList() {
+		required++
+
+		if signer == t.Payer {
+			if envelopeSigners[signer] {
+				collected++
+			}
+			continue
+		}
+
+		if payloadSigners[signer] {
+			collected++
+		}
 	}
 
-	return signers
+	return collected, required
 }
 
 // signerMap returns a mapping from address to signer index.
@@ -232,14 +928,49 @@ func (t *Transaction) signerMap() map[Address]int {
 	return signers
 }
 
+// RebuildSignatureIndexes recomputes the signer index of every payload and envelope signature
+// from the current proposer, payer and authorizers of this transaction.
+//
+// Signer indexes are assigned when a signature is added, so they become stale if signing roles
+// are modified afterwards.
+//
+// This function returns an error, and leaves all signatures unchanged, if any signature belongs
+// to an address that is no longer a signer of this transaction.
+func (t *Transaction) RebuildSignatureIndexes() error {
+	signers := t.signerMap()
+
+	for _, sigs := range [][]TransactionSignature{t.PayloadSignatures, t.EnvelopeSignatures} {
+		for _, sig := range sigs {
+			if _, ok := signers[sig.Address]; !ok {
+				return fmt.Errorf("signature address %s is not a signer of this transaction", sig.Address)
+			}
+		}
+	}
+
+	for i, sig := range t.PayloadSignatures {
+		t.PayloadSignatures[i].SignerIndex = signers[sig.Address]
+	}
+
+	for i, sig := range t.EnvelopeSignatures {
+		t.EnvelopeSignatures[i].SignerIndex = signers[sig.Address]
+	}
+
+	sort.Slice(t.PayloadSignatures, compareSignatures(t.PayloadSignatures))
+	sort.Slice(t.EnvelopeSignatures, compareSignatures(t.EnvelopeSignatures))
+
+	return nil
+}
+
 // SignPayload signs the transaction payload with the specified account key.
 //
+// The message is prefixed with TransactionDomainTag before it is passed to the signer.
+//
 // The resulting signature is combined with the account address and key index before
 // being added to the transaction.
 //
 // This function returns an error if the signature cannot be generated.
 func (t *Transaction) SignPayload(address Address, keyIndex int, signer crypto.Signer) error {
-	sig, err := signer.Sign(t.PayloadMessage())
+	sig, err := SignPayloadMessage(t.PayloadMessage(), signer)
 	if err != nil {
 		// TODO: wrap error
 		return err
@@ -252,12 +983,14 @@ func (t *Transaction) SignPayload(address Address, keyIndex int, signer crypto.S
 
 // SignEnvelope signs the full transaction (payload + payload signatures) with the specified account key.
 //
+// The message is prefixed with TransactionDomainTag before it is passed to the signer.
+//
 // The resulting signature is combined with the account address and key index before
 // being added to the transaction.
 //
 // This function returns an error if the signature cannot be generated.
 func (t *Transaction) SignEnvelope(address Address, keyIndex int, signer crypto.Signer) error {
-	sig, err := signer.Sign(t.EnvelopeMessage())
+	sig, err := SignEnvelopeMessage(t.EnvelopeMessage(), signer)
 	if err != nil {
 		// TODO: wrap error
 		return err
@@ -268,26 +1001,144 @@ func (t *Transaction) SignEnvelope(address Address, keyIndex int, signer crypto.
 	return nil
 }
 
+// TransferSignaturesFrom replaces the payload and envelope signatures of this transaction
+// with copies of the signatures of another transaction.
+//
+// This function returns an error, and leaves the signatures unchanged, if the payloads of
+// the two transactions differ, since the signatures would not be valid for this transaction.
+func (t *Transaction) TransferSignaturesFrom(src *Transaction) error {
+	if !bytes.Equal(t.PayloadMessage(), src.PayloadMessage()) {
+		return fmt.Errorf("cannot transfer signatures: transaction payloads differ")
+	}
+
+	t.PayloadSignatures = copySignatures(src.PayloadSignatures)
+	t.EnvelopeSignatures = copySignatures(src.EnvelopeSignatures)
+
+	return nil
+}
+
 // AddPayloadSignature adds a payload signature to the transaction for the given address and key index.
+//
+// A signature that was previously added for the same signer and key index is replaced.
 func (t *Transaction) AddPayloadSignature(address Address, keyIndex int, sig []byte) *Transaction {
 	s := t.createSignature(address, keyIndex, sig)
 
-	t.PayloadSignatures = append(t.PayloadSignatures, s)
-	sort.Slice(t.PayloadSignatures, compareSignatures(t.PayloadSignatures))
+	t.PayloadSignatures = addSignature(t.PayloadSignatures, s)
 
 	return t
 }
 
 // AddEnvelopeSignature adds an envelope signature to the transaction for the given address and key index.
+//
+// A signature that was previously added for the same signer and key index is replaced.
 func (t *Transaction) AddEnvelopeSignature(address Address, keyIndex int, sig []byte) *Transaction {
+	t.recordEnvelopeMessage()
+
 	s := t.createSignature(address, keyIndex, sig)
 
-	t.EnvelopeSignatures = append(t.EnvelopeSignatures, s)
-	sort.Slice(t.EnvelopeSignatures, compareSignatures(t.EnvelopeSignatures))
+	t.EnvelopeSignatures = addSignature(t.EnvelopeSignatures, s)
 
 	return t
 }
 
+// recordEnvelopeMessage records the envelope message that an envelope signature is
+// about to be added for, so that CheckSigningOrder can detect later changes.
+func (t *Transaction) recordEnvelopeMessage() {
+	message := t.EnvelopeMessage()
+
+	if len(t.EnvelopeSignatures) == 0 {
+		t.signedEnvelopeMessage = message
+		t.envelopeMessageChanged = false
+		return
+	}
+
+	if t.signedEnvelopeMessage != nil && !bytes.Equal(message, t.signedEnvelopeMessage) {
+		t.envelopeMessageChanged = true
+	}
+}
+
+// CheckSigningOrder returns an error if the envelope signatures of this transaction were
+// invalidated by changes made after the envelope was signed.
+//
+// The envelope message includes the payload signatures, so adding a payload signature or
+// modifying the payload after signing the envelope invalidates the envelope signatures.
+//
+// Changes can only be detected for envelope signatures added to this transaction value;
+// signatures restored by decoding a transaction are not checked. Use VerifySignatures to
+// check all signatures against the account keys of the signers.
+func (t *Transaction) CheckSigningOrder() error {
+	if len(t.EnvelopeSignatures) == 0 || t.signedEnvelopeMessage == nil {
+		return nil
+	}
+
+	if t.envelopeMessageChanged {
+		return fmt.Errorf("envelope signatures were added over different envelope messages")
+	}
+
+	if !bytes.Equal(t.EnvelopeMessage(), t.signedEnvelopeMessage) {
+		return fmt.Errorf(
+			"envelope was modified after it was signed; " +
+				"payload signatures must be added before envelope signatures",
+		)
+	}
+
+	return nil
+}
+
+// addSignature adds a signature to a sorted signature list, replacing any signature
+// from the same signer and key index.
+func addSignature(signatures []TransactionSignature, s TransactionSignature) []TransactionSignature {
+	for i, existing := range signatures {
+		if existing.SignerIndex == s.SignerIndex &&
+			existing.KeyIndex == s.KeyIndex &&
+			existing.Address == s.Address {
+			signatures[i] = s
+			return signatures
+		}
+	}
+
+	signatures = append(signatures, s)
+	sort.Slice(signatures, compareSignatures(signatures))
+
+	return signatures
+}
+
+// AddPayloadSignatureChecked adds a payload signature to the transaction for the given
+// address and key index.
+//
+// This function returns an error if the address is not the proposer, payer or an
+// authorizer of this transaction.
+func (t *Transaction) AddPayloadSignatureChecked(address Address, keyIndex int, sig []byte) error {
+	if err := t.checkSigner(address); err != nil {
+		return err
+	}
+
+	t.AddPayloadSignature(address, keyIndex, sig)
+	return nil
+}
+
+// AddEnvelopeSignatureChecked adds an envelope signature to the transaction for the given
+// address and key index.
+//
+// This function returns an error if the address is not the proposer, payer or an
+// authorizer of this transaction.
+func (t *Transaction) AddEnvelopeSignatureChecked(address Address, keyIndex int, sig []byte) error {
+	if err := t.checkSigner(address); err != nil {
+		return err
+	}
+
+	t.AddEnvelopeSignature(address, keyIndex, sig)
+	return nil
+}
+
+func (t *Transaction) checkSigner(address Address) error {
+	if _, ok := t.signerMap()[address]; !ok {
+		return fmt.Errorf("address %s is not a signer of this transaction", address)
+	}
+
+	return nil
+}
+
 func (t *Transaction) createSignature(address Address, keyIndex int, sig []byte) TransactionSignature {
 	signerIndex, signerExists := t.signerMap()[address]
 	if !signerExists {
@@ -344,6 +1195,20 @@ func (t *Transaction) EnvelopeMessage() []byte {
 	return mustRLPEncode(&temp)
 }
 
+// PayloadMessageHash returns the SHA3-256 hash of the payload message of this transaction.
+//
+// The hash is computed over PayloadMessage without the transaction domain tag.
+func (t *Transaction) PayloadMessageHash() []byte {
+	return defaultEntityHasher.ComputeHash(t.PayloadMessage())
+}
+
+// EnvelopeMessageHash returns the SHA3-256 hash of the envelope message of this transaction.
+//
+// The hash is computed over EnvelopeMessage without the transaction domain tag.
+func (t *Transaction) EnvelopeMessageHash() []byte {
+	return defaultEntityHasher.ComputeHash(t.EnvelopeMessage())
+}
+
 func (t *Transaction) envelopeCanonicalForm() interface{} {
 	return struct {
 		Payload           interface{}
@@ -354,8 +1219,223 @@ func (t *Transaction) envelopeCanonicalForm() interface{} {
 	}
 }
 
+// VerifyProposalConsistency returns an error if the proposal key of this transaction has not signed it.
+//
+// The proposer must sign the payload with the exact key referenced by the proposal key. If the
+// proposer is also the payer, an envelope signature with that key is accepted instead.
+//
+// This function only checks that a signature is present; it does not verify the signature itself.
+func (t *Transaction) VerifyProposalConsistency() error {
+	hasSignature := func(sigs []TransactionSignature) bool {
+		for _, sig := range sigs {
+			if sig.Address == t.ProposalKey.Address && sig.KeyIndex == t.ProposalKey.KeyIndex {
+				return true
+			}
+		}
+		return false
+	}
+
+	if hasSignature(t.PayloadSignatures) {
+		return nil
+	}
+
+	if t.ProposalKey.Address == t.Payer && hasSignature(t.EnvelopeSignatures) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"missing proposer signature from %s with key index %d",
+		t.ProposalKey.Address,
+		t.ProposalKey.KeyIndex,
+	)
+}
+
+// VerifyEnvelopeSignedOnlyByPayer returns an error if an envelope signature of this
+// transaction was produced by an account other than the payer.
+//
+// This function does not check that the payer has signed the envelope, and it does not
+// verify the signatures themselves.
+func (t *Transaction) VerifyEnvelopeSignedOnlyByPayer() error {
+	for _, sig := range t.EnvelopeSignatures {
+		if sig.Address != t.Payer {
+			return fmt.Errorf(
+				"envelope signature from %s with key index %d does not belong to payer %s",
+				sig.Address,
+				sig.KeyIndex,
+				t.Payer,
+			)
+		}
+	}
+
+	return nil
+}
+
+// VerifySignatures verifies each payload signature against the payload message and each
+// envelope signature against the envelope message.
+//
+// Signatures are verified with the public key at the referenced key index of the signing
+// account, as found in keysByAddress.
+//
+// This function returns false and an error identifying the first signature that is
+// invalid, or that cannot be verified because its account key is missing.
+func (t *Transaction) VerifySignatures(keysByAddress map[Address][]AccountKey) (bool, error) {
+	payloadMessage := t.PayloadMessage()
+
+	for i, sig := range t.PayloadSignatures {
+		err := verifySignature(sig, payloadMessage, keysByAddress, VerifyPayloadMessage)
+		if err != nil {
+			return false, fmt.Errorf("payload signature %d: %w", i, err)
+		}
+	}
+
+	envelopeMessage := t.EnvelopeMessage()
+
+	for i, sig := range t.EnvelopeSignatures {
+		err := verifySignature(sig, envelopeMessage, keysByAddress, VerifyEnvelopeMessage)
+		if err != nil {
+			return false, fmt.Errorf("envelope signature %d: %w", i, err)
+		}
+	}
+
+	return true, nil
+}
+
+// verifySignature verifies a transaction signature over a message with the given
+// verification function, returning an error if the signature is not valid.
+func verifySignature(
+	sig TransactionSignature,
+	message []byte,
+	keysByAddress map[Address][]AccountKey,
+	verify func(message, signature []byte, publicKey crypto.PublicKey, hasher crypto.Hasher) (bool, error),
+) error {
+	var key *AccountKey
+	for i := range keysByAddress[sig.Address] {
+		if keysByAddress[sig.Address][i].Index == sig.KeyIndex {
+			key = &keysByAddress[sig.Address][i]
+			break
+		}
+	}
+
+	if key == nil {
+		return fmt.Errorf("no key with index %d for address %s", sig.KeyIndex, sig.Address)
+	}
+
+	hasher, err := crypto.NewHasher(key.HashAlgo)
+	if err != nil {
+		return fmt.Errorf("key %d of address %s: %w", sig.KeyIndex, sig.Address, err)
+	}
+
+	valid, err := verify(message, sig.Signature, key.PublicKey, hasher)
+	if err != nil {
+		return fmt.Errorf("failed to verify signature of address %s with key %d: %w", sig.Address, sig.KeyIndex, err)
+	}
+
+	if !valid {
+		return fmt.Errorf("invalid signature of address %s with key %d", sig.Address, sig.KeyIndex)
+	}
+
+	return nil
+}
+
+// String returns a multi-line, human-readable summary of this transaction.
+//
+// The script is summarized by its length and SHA3-256 hash rather than included in full,
+// and signatures are summarized by their count. Use SigningDebugDump for the full signing
+// context of a transaction.
+func (t *Transaction) String() string {
+	var b strings.Builder
+
+	authorizers := make([]string, len(t.Authorizers))
+	for i, authorizer := range t.Authorizers {
+		authorizers[i] = authorizer.Hex()
+	}
+
+	fmt.Fprintf(&b, "Transaction %s\n", t.ID())
+	fmt.Fprintf(&b, "  Script: %d bytes, hash %s\n", len(t.Script), defaultEntityHasher.ComputeHash(t.Script))
+	fmt.Fprintf(&b, "  Arguments: %d\n", len(t.Arguments))
+	fmt.Fprintf(&b, "  Reference block ID: %s\n", t.ReferenceBlockID)
+	fmt.Fprintf(&b, "  Gas limit: %d\n", t.GasLimit)
+	fmt.Fprintf(
+		&b,
+		"  Proposal key: address=%s keyIndex=%d sequenceNumber=%d\n",
+		t.ProposalKey.Address,
+		t.ProposalKey.KeyIndex,
+		t.ProposalKey.SequenceNumber,
+	)
+	fmt.Fprintf(&b, "  Payer: %s\n", t.Payer)
+	fmt.Fprintf(&b, "  Authorizers: [%s]\n", strings.Join(authorizers, ", "))
+	fmt.Fprintf(&b, "  Payload signatures: %d\n", len(t.PayloadSignatures))
+	fmt.Fprintf(&b, "  Envelope signatures: %d\n", len(t.EnvelopeSignatures))
+
+	return b.String()
+}
+
+// SigningDebugDump returns a human-readable description of the signing context of this transaction.
+//
+// The description includes the transaction ID, the hex encoded payload and envelope messages,
+// and the signer, key and role of each signature. It contains everything needed to reproduce
+// a signing issue and is intended for logging and support requests.
+func (t *Transaction) SigningDebugDump() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Transaction ID: %s\n", t.ID())
+	fmt.Fprintf(&b, "Payload message: %x\n", t.PayloadMessage())
+	fmt.Fprintf(&b, "Envelope message: %x\n", t.EnvelopeMessage())
+
+	b.WriteString("Payload signatures:\n")
+	for i, sig := range t.PayloadSignatures {
+		t.writeSignatureDebug(&b, i, sig, t.payloadSignatureRole(sig))
+	}
+
+	b.WriteString("Envelope signatures:\n")
+	for i, sig := range t.EnvelopeSignatures {
+		t.writeSignatureDebug(&b, i, sig, "payer")
+	}
+
+	return b.String()
+}
+
+func (t *Transaction) payloadSignatureRole(sig TransactionSignature) string {
+	roles := make([]string, 0)
+
+	if sig.Address == t.ProposalKey.Address && sig.KeyIndex == t.ProposalKey.KeyIndex {
+		roles = append(roles, "proposer")
+	}
+
+	for _, authorizer := range t.Authorizers {
+		if sig.Address == authorizer {
+			roles = append(roles, "authorizer")
+			break
+		}
+	}
+
+	if len(roles) == 0 {
+		return "unknown"
+	}
+
+	return strings.Join(roles, ",")
+}
+
+func (t *Transaction) writeSignatureDebug(b *strings.Builder, i int, sig TransactionSignature, role string) {
+	fmt.Fprintf(
+		b,
+		"  [%d] address=%s signerIndex=%d keyIndex=%d role=%s signature=%x\n",
+		i,
+		sig.Address,
+		sig.SignerIndex,
+		sig.KeyIndex,
+		role,
+		sig.Signature,
+	)
+}
+
 // Encode serializes the full transaction data including the payload and all signatures.
 func (t *Transaction) Encode() []byte {
+	if hook := loadEncodingMetricsHook(); hook != nil {
+		start := time.Now()
+		defer func() { hook(EncodingOpEncode, time.Since(start)) }()
+	}
+
 	temp := struct {
 		Payload            interface{}
 		PayloadSignatures  interface{}
@@ -371,13 +1451,18 @@ func (t *Transaction) Encode() []byte {
 
 // DecodeFromBytes un-serializes from raw data to the full transaction data
 func (t *Transaction) DecodeFromBytes(bs []byte) error {
+	if hook := loadEncodingMetricsHook(); hook != nil {
+		start := time.Now()
+		defer func() { hook(EncodingOpDecode, time.Since(start)) }()
+	}
+
 	type payload struct {
 		Script                    []byte
 		Arguments                 [][]byte
 		ReferenceBlockID          []byte
 		GasLimit                  uint64
 		ProposalKeyAddress        []byte
-		ProposalKeyID             uint64
+		ProposalKeyIndex          uint64
 		ProposalKeySequenceNumber uint64
 		Payer                     []byte
 		Authorizers               [][]byte
@@ -385,7 +1470,7 @@ func (t *Transaction) DecodeFromBytes(bs []byte) error {
 
 	type signature struct {
 		SignerIndex uint
-		KeyID       uint
+		KeyIndex    uint
 		Signature   []byte
 	}
 
@@ -405,39 +1490,57 @@ func (t *Transaction) DecodeFromBytes(bs []byte) error {
 	copy(tempReferenceBlockID[:], temp.Payload.ReferenceBlockID)
 	t.ReferenceBlockID = tempReferenceBlockID
 	t.GasLimit = temp.Payload.GasLimit
-	var tempProposalKeyAddress [8]byte
-	copy(tempProposalKeyAddress[:], temp.Payload.ProposalKeyAddress)
-	t.ProposalKey.Address = tempProposalKeyAddress
-	t.ProposalKey.KeyIndex = int(temp.Payload.ProposalKeyID)
+	proposalKeyAddress, err := NormalizeAddress(temp.Payload.ProposalKeyAddress)
+	if err != nil {
+		return fmt.Errorf("invalid proposal key address: %w", err)
+	}
+	t.ProposalKey.Address = proposalKeyAddress
+	t.ProposalKey.KeyIndex = int(temp.Payload.ProposalKeyIndex)
 	t.ProposalKey.SequenceNumber = temp.Payload.ProposalKeySequenceNumber
-	var tempPayer [8]byte
-	copy(tempPayer[:], temp.Payload.Payer)
-	t.Payer = tempPayer
+	payer, err := NormalizeAddress(temp.Payload.Payer)
+	if err != nil {
+		return fmt.Errorf("invalid payer address: %w", err)
+	}
+	t.Payer = payer
 	t.Arguments = temp.Payload.Arguments
 
 	t.Authorizers = make([]Address, len(temp.Payload.Authorizers))
 	for i, auth := range temp.Payload.Authorizers {
-		var tempAuth [8]byte
-		copy(tempAuth[:], auth)
-		t.Authorizers[i] = tempAuth
+		authorizer, err := NormalizeAddress(auth)
+		if err != nil {
+			return fmt.Errorf("invalid authorizer address at index %d: %w", i, err)
+		}
+		t.Authorizers[i] = authorizer
 	}
 
+	signers := t.signerList()
+
 	t.PayloadSignatures = make([]TransactionSignature, len(temp.PayloadSignatures))
 	for i, sig := range temp.PayloadSignatures {
+		address, err := signerAddress(signers, sig.SignerIndex)
+		if err != nil {
+			return fmt.Errorf("invalid payload signature at index %d: %w", i, err)
+		}
+
 		t.PayloadSignatures[i] = TransactionSignature{
-			Address:     t.signerList()[sig.SignerIndex],
+			Address:     address,
 			SignerIndex: int(sig.SignerIndex),
-			KeyIndex:    int(sig.KeyID),
+			KeyIndex:    int(sig.KeyIndex),
 			Signature:   sig.Signature,
 		}
 	}
 
 	t.EnvelopeSignatures = make([]TransactionSignature, len(temp.EnvelopeSignatures))
 	for i, sig := range temp.EnvelopeSignatures {
+		address, err := signerAddress(signers, sig.SignerIndex)
+		if err != nil {
+			return fmt.Errorf("invalid envelope signature at index %d: %w", i, err)
+		}
+
 		t.EnvelopeSignatures[i] = TransactionSignature{
-			Address:     t.signerList()[sig.SignerIndex],
+			Address:     address,
 			SignerIndex: int(sig.SignerIndex),
-			KeyIndex:    int(sig.KeyID),
+			KeyIndex:    int(sig.KeyIndex),
 			Signature:   sig.Signature,
 		}
 	}
@@ -445,6 +1548,47 @@ func (t *Transaction) DecodeFromBytes(bs []byte) error {
 	return nil
 }
 
+// DecodeTransaction decodes a full transaction from its canonical form, as returned by Encode.
+func DecodeTransaction(b []byte) (*Transaction, error) {
+	t := NewTransaction()
+
+	err := t.DecodeFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// DecodeTransactionHex decodes a full transaction from its hex-encoded canonical form.
+//
+// The string may optionally be prefixed with "0x".
+func DecodeTransactionHex(s string) (*Transaction, error) {
+	if has0xPrefix(s) {
+		s = s[2:]
+	}
+
+	if len(s) == 0 {
+		return nil, fmt.Errorf("encoded transaction is empty")
+	}
+
+	if len(s)%2 == 1 {
+		return nil, fmt.Errorf("encoded transaction has odd length %d", len(s))
+	}
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction hex: %w", err)
+	}
+
+	t, err := DecodeTransaction(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	return t, nil
+}
+
 // DecodeFromPayloadBytes un-serializes from payload raw data to the full transaction data
 func (t *Transaction) DecodeFromPayloadBytes(bs []byte) error {
 	type payload struct {
@@ -453,7 +1597,7 @@ func (t *Transaction) DecodeFromPayloadBytes(bs []byte) error {
 		ReferenceBlockID          []byte
 		GasLimit                  uint64
 		ProposalKeyAddress        []byte
-		ProposalKeyID             uint64
+		ProposalKeyIndex          uint64
 		ProposalKeySequenceNumber uint64
 		Payer                     []byte
 		Authorizers               [][]byte
@@ -461,7 +1605,7 @@ func (t *Transaction) DecodeFromPayloadBytes(bs []byte) error {
 
 	type signature struct {
 		SignerIndex uint
-		KeyID       uint
+		KeyIndex    uint
 		Signature   []byte
 	}
 
@@ -480,31 +1624,42 @@ func (t *Transaction) DecodeFromPayloadBytes(bs []byte) error {
 	copy(tempReferenceBlockID[:], temp.Payload.ReferenceBlockID)
 	t.ReferenceBlockID = tempReferenceBlockID
 	t.GasLimit = temp.Payload.GasLimit
-	var tempProposalKeyAddress [8]byte
-	copy(tempProposalKeyAddress[:], temp.Payload.ProposalKeyAddress)
-	t.ProposalKey.Address = tempProposalKeyAddress
-	t.ProposalKey.KeyIndex = int(temp.Payload.ProposalKeyID)
+	proposalKeyAddress, err := NormalizeAddress(temp.Payload.ProposalKeyAddress)
+	if err != nil {
+		return fmt.Errorf("invalid proposal key address: %w", err)
+	}
+	t.ProposalKey.Address = proposalKeyAddress
+	t.ProposalKey.KeyIndex = int(temp.Payload.ProposalKeyIndex)
 	t.ProposalKey.SequenceNumber = temp.Payload.ProposalKeySequenceNumber
-	var tempAddress [8]byte
-	copy(tempAddress[:], temp.Payload.ProposalKeyAddress)
-	var tempPayer [8]byte
-	copy(tempPayer[:], temp.Payload.Payer)
-	t.Payer = tempPayer
+	payer, err := NormalizeAddress(temp.Payload.Payer)
+	if err != nil {
+		return fmt.Errorf("invalid payer address: %w", err)
+	}
+	t.Payer = payer
 	t.Arguments = temp.Payload.Arguments
 
 	t.Authorizers = make([]Address, len(temp.Payload.Authorizers))
 	for i, auth := range temp.Payload.Authorizers {
-		var tempAuth [8]byte
-		copy(tempAuth[:], auth)
-		t.Authorizers[i] = tempAddress
+		authorizer, err := NormalizeAddress(auth)
+		if err != nil {
+			return fmt.Errorf("invalid authorizer address at index %d: %w", i, err)
+		}
+		t.Authorizers[i] = authorizer
 	}
 
+	signers := t.signerList()
+
 	t.PayloadSignatures = make([]TransactionSignature, len(temp.PayloadSignatures))
 	for i, sig := range temp.PayloadSignatures {
+		address, err := signerAddress(signers, sig.SignerIndex)
+		if err != nil {
+			return fmt.Errorf("invalid payload signature at index %d: %w", i, err)
+		}
+
 		t.PayloadSignatures[i] = TransactionSignature{
-			Address:     t.signerList()[sig.SignerIndex],
+			Address:     address,
 			SignerIndex: int(sig.SignerIndex),
-			KeyIndex:    int(sig.KeyID),
+			KeyIndex:    int(sig.KeyIndex),
 			Signature:   sig.Signature,
 		}
 	}
@@ -512,6 +1667,25 @@ func (t *Transaction) DecodeFromPayloadBytes(bs []byte) error {
 	return nil
 }
 
+// DecodeFromEnvelopeBytes un-serializes a transaction from its envelope message, as
+// returned by EnvelopeMessage.
+//
+// The envelope message contains the payload and the payload signatures, which is what a
+// payer receives from the other signers. Payload signatures are restored in their encoded
+// order, so EnvelopeMessage on the decoded transaction reproduces the original bytes.
+func (t *Transaction) DecodeFromEnvelopeBytes(bs []byte) error {
+	return t.DecodeFromPayloadBytes(bs)
+}
+
+// signerAddress returns the address of the signer at the given index of a signer list.
+func signerAddress(signers []Address, index uint) (Address, error) {
+	if index >= uint(len(signers)) {
+		return EmptyAddress, fmt.Errorf("signer index %d is out of range for %d signers", index, len(signers))
+	}
+
+	return signers[index], nil
+}
+
 // A ProposalKey is the key that specifies the proposal key and sequence number for a transaction.
 type ProposalKey struct {
 	Address        Address
@@ -543,7 +1717,11 @@ func compareSignatures(signatures []TransactionSignature) func(i, j int) bool {
 	return func(i, j int) bool {
 		sigA := signatures[i]
 		sigB := signatures[j]
-		return sigA.SignerIndex < sigB.SignerIndex || sigA.KeyIndex < sigB.KeyIndex
+		if sigA.SignerIndex != sigB.SignerIndex {
+			return sigA.SignerIndex < sigB.SignerIndex
+		}
+
+		return sigA.KeyIndex < sigB.KeyIndex
 	}
 }
 
@@ -583,7 +1761,36 @@ const (
 	TransactionStatusExpired
 )
 
+var transactionStatusStrings = [...]string{"UNKNOWN", "PENDING", "FINALIZED", "EXECUTED", "SEALED", "EXPIRED"}
+
 // String returns the string representation of a transaction status.
 func (s TransactionStatus) String() string {
-	return [...]string{"UNKNOWN", "PENDING", "FINALIZED", "EXECUTED", "SEALED", "EXPIRED"}[s]
+	return transactionStatusStrings[s]
+}
+
+// ParseTransactionStatus parses a transaction status from its string representation,
+// as returned by String.
+//
+// This function returns an error if the string is not a known transaction status.
+func ParseTransactionStatus(s string) (TransactionStatus, error) {
+	for i, str := range transactionStatusStrings {
+		if s == str {
+			return TransactionStatus(i), nil
+		}
+	}
+
+	return TransactionStatusUnknown, fmt.Errorf("unknown transaction status %q", s)
+}
+
+// IsFinal returns true if the transaction status can no longer change.
+//
+// A transaction is final once it is sealed or expired.
+func (s TransactionStatus) IsFinal() bool {
+	return s == TransactionStatusSealed || s == TransactionStatusExpired
+}
+
+// IsError returns true if the transaction status indicates that the transaction will
+// never be executed.
+func (s TransactionStatus) IsError() bool {
+	return s == TransactionStatusExpired
 }
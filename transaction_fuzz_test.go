@@ -0,0 +1,50 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+)
+
+func FuzzTransactionRoundTrip(f *testing.F) {
+	f.Add([]byte("transaction {}"), []byte(`{"type":"Int","value":"1"}`), uint64(9999), uint16(3), uint64(42), uint64(1), uint64(2), uint64(3), []byte{4, 5, 6})
+	f.Add([]byte{}, []byte{}, uint64(0), uint16(0), uint64(0), uint64(7), uint64(7), uint64(7), []byte{})
+
+	f.Fuzz(func(
+		t *testing.T,
+		script []byte,
+		argument []byte,
+		gasLimit uint64,
+		keyIndex uint16,
+		sequenceNumber uint64,
+		proposer, payer, authorizer uint64,
+		signature []byte,
+	) {
+		if proposer == 0 || payer == 0 || authorizer == 0 {
+			// empty addresses are not signers, so signatures cannot be attributed to them
+			t.Skip()
+		}
+
+		tx := roundTripTransaction(script, argument, gasLimit, keyIndex, sequenceNumber, proposer, payer, authorizer, signature)
+		assertTransactionRoundTrip(t, tx)
+	})
+}
@@ -0,0 +1,109 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countDecodes replaces the argument decoder with one that counts the number of decoded arguments.
+//
+// The returned function restores the original decoder.
+func countDecodes() (*int, func()) {
+	count := 0
+
+	decodeArgument = func(b []byte) (cadence.Value, error) {
+		count++
+		return jsoncdc.Decode(b)
+	}
+
+	return &count, func() {
+		decodeArgument = jsoncdc.Decode
+	}
+}
+
+func TestTransaction_ArgumentCaching(t *testing.T) {
+	t.Run("Cached", func(t *testing.T) {
+		decodes, restore := countDecodes()
+		defer restore()
+
+		tx := NewTransaction().SetArgumentCaching(true)
+
+		err := tx.AddArgument(cadence.NewString("foo"))
+		require.NoError(t, err)
+
+		err = tx.AddArgument(cadence.NewInt(42))
+		require.NoError(t, err)
+
+		arg, err := tx.Argument(1)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(42), arg)
+
+		arg, err = tx.Argument(1)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(42), arg)
+
+		assert.Equal(t, 1, *decodes)
+	})
+
+	t.Run("Invalidated", func(t *testing.T) {
+		decodes, restore := countDecodes()
+		defer restore()
+
+		tx := NewTransaction().SetArgumentCaching(true)
+
+		err := tx.AddArgument(cadence.NewString("foo"))
+		require.NoError(t, err)
+
+		_, err = tx.Argument(0)
+		require.NoError(t, err)
+
+		tx.Arguments[0], err = jsoncdc.Encode(cadence.NewString("bar"))
+		require.NoError(t, err)
+
+		arg, err := tx.Argument(0)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewString("bar"), arg)
+
+		assert.Equal(t, 2, *decodes)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		decodes, restore := countDecodes()
+		defer restore()
+
+		tx := NewTransaction()
+
+		err := tx.AddArgument(cadence.NewString("foo"))
+		require.NoError(t, err)
+
+		_, err = tx.Argument(0)
+		require.NoError(t, err)
+
+		_, err = tx.Argument(0)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, *decodes)
+	})
+}
@@ -19,8 +19,11 @@
 package flow_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/onflow/cadence"
@@ -228,6 +231,40 @@ func TestTransaction_AddRawArgument(t *testing.T) {
 	})
 }
 
+func TestTransaction_MaxArguments(t *testing.T) {
+	t.Run("Default limit", func(t *testing.T) {
+		tx := flow.NewTransaction()
+
+		for i := 0; i < flow.MaxArguments; i++ {
+			err := tx.AddRawArgumentChecked([]byte{1})
+			require.NoError(t, err)
+		}
+
+		err := tx.AddRawArgumentChecked([]byte{1})
+		assert.Error(t, err)
+
+		err = tx.AddArgument(cadence.NewInt(42))
+		assert.Error(t, err)
+
+		assert.Len(t, tx.Arguments, flow.MaxArguments)
+	})
+
+	t.Run("Overridden limit", func(t *testing.T) {
+		tx := flow.NewTransaction().SetMaxArguments(2)
+
+		err := tx.AddArgument(cadence.NewInt(1))
+		require.NoError(t, err)
+
+		err = tx.AddArgument(cadence.NewInt(2))
+		require.NoError(t, err)
+
+		err = tx.AddArgument(cadence.NewInt(3))
+		assert.Error(t, err)
+
+		assert.Len(t, tx.Arguments, 2)
+	})
+}
+
 func TestTransaction_SetReferenceBlockID(t *testing.T) {
 	blockID := test.IdentifierGenerator().New()
 
@@ -288,6 +325,40 @@ func TestTransaction_AddAuthorizer(t *testing.T) {
 	assert.NotEqual(t, addressB, addressA)
 }
 
+func TestTransaction_SetPayerAsAuthorizer(t *testing.T) {
+	addresses := test.AddressGenerator()
+
+	t.Run("Payer not yet an authorizer", func(t *testing.T) {
+		payer := addresses.New()
+		authorizer := addresses.New()
+
+		tx := flow.NewTransaction().
+			AddAuthorizer(authorizer).
+			SetPayer(payer).
+			SetPayerAsAuthorizer()
+
+		assert.Equal(t, []flow.Address{authorizer, payer}, tx.Authorizers)
+	})
+
+	t.Run("Payer already an authorizer", func(t *testing.T) {
+		payer := addresses.New()
+
+		tx := flow.NewTransaction().
+			SetPayer(payer).
+			AddAuthorizer(payer).
+			SetPayerAsAuthorizer().
+			SetPayerAsAuthorizer()
+
+		assert.Equal(t, []flow.Address{payer}, tx.Authorizers)
+	})
+
+	t.Run("Payer not set", func(t *testing.T) {
+		tx := flow.NewTransaction().SetPayerAsAuthorizer()
+
+		assert.Empty(t, tx.Authorizers)
+	})
+}
+
 func TestTransaction_AddPayloadSignature(t *testing.T) {
 	addresses := test.AddressGenerator()
 
@@ -457,6 +528,50 @@ func TestTransaction_AddEnvelopeSignature(t *testing.T) {
 	})
 }
 
+func TestTransaction_RebuildSignatureIndexes(t *testing.T) {
+	addresses := test.AddressGenerator()
+
+	proposer := addresses.New()
+	payer := addresses.New()
+	authorizerA := addresses.New()
+	authorizerB := addresses.New()
+
+	newTx := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetProposalKey(proposer, 0, 42).
+			SetPayer(payer).
+			AddAuthorizer(authorizerB).
+			AddPayloadSignature(authorizerB, 0, []byte{1}).
+			AddEnvelopeSignature(payer, 0, []byte{2})
+	}
+
+	t.Run("Authorizers modified", func(t *testing.T) {
+		tx := newTx()
+
+		require.Equal(t, 2, tx.PayloadSignatures[0].SignerIndex)
+
+		tx.Authorizers = []flow.Address{authorizerA, authorizerB}
+
+		err := tx.RebuildSignatureIndexes()
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, tx.PayloadSignatures[0].SignerIndex)
+		assert.Equal(t, 1, tx.EnvelopeSignatures[0].SignerIndex)
+	})
+
+	t.Run("Signer removed", func(t *testing.T) {
+		tx := newTx()
+
+		tx.Authorizers = []flow.Address{authorizerA}
+
+		err := tx.RebuildSignatureIndexes()
+		assert.Error(t, err)
+
+		// signatures are left unchanged
+		assert.Equal(t, 2, tx.PayloadSignatures[0].SignerIndex)
+	})
+}
+
 func baseTx() *flow.Transaction {
 	sig, _ := hex.DecodeString("f7225388c1d69d57e6251c9fda50cbbf9e05131e5adb81e5aa0422402f048162")
 	return flow.NewTransaction().
@@ -573,3 +688,1060 @@ func TestTransaction_Encode(t *testing.T) {
 	assert.Equal(t, tx.EnvelopeSignatures, newTx.EnvelopeSignatures)
 	assert.Equal(t, tx.PayloadSignatures, newTx.PayloadSignatures)
 }
+
+func TestDecodeTransactionHex(t *testing.T) {
+	tx := baseTx().
+		AddEnvelopeSignature(flow.HexToAddress("01"), 4, []byte{0x12})
+
+	encoded := hex.EncodeToString(tx.Encode())
+
+	t.Run("Valid", func(t *testing.T) {
+		decodedTx, err := flow.DecodeTransactionHex(encoded)
+		require.NoError(t, err)
+
+		assert.Equal(t, tx.ID(), decodedTx.ID())
+		assert.Equal(t, tx.Script, decodedTx.Script)
+		assert.Equal(t, tx.PayloadSignatures, decodedTx.PayloadSignatures)
+		assert.Equal(t, tx.EnvelopeSignatures, decodedTx.EnvelopeSignatures)
+	})
+
+	t.Run("Prefixed", func(t *testing.T) {
+		decodedTx, err := flow.DecodeTransactionHex("0x" + encoded)
+		require.NoError(t, err)
+
+		assert.Equal(t, tx.ID(), decodedTx.ID())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := flow.DecodeTransactionHex("")
+		assert.Error(t, err)
+	})
+
+	t.Run("Odd length", func(t *testing.T) {
+		_, err := flow.DecodeTransactionHex(encoded[1:])
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid hex", func(t *testing.T) {
+		_, err := flow.DecodeTransactionHex("zz" + encoded[2:])
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid encoding", func(t *testing.T) {
+		_, err := flow.DecodeTransactionHex("abcd")
+		assert.Error(t, err)
+	})
+}
+
+func TestTransaction_ExceedsMaxGas(t *testing.T) {
+	tx := flow.NewTransaction().SetGasLimit(flow.MaxGasLimit)
+	assert.False(t, tx.ExceedsMaxGas(flow.MaxGasLimit))
+
+	tx.SetGasLimit(flow.MaxGasLimit + 1)
+	assert.True(t, tx.ExceedsMaxGas(flow.MaxGasLimit))
+}
+
+func TestTransaction_Fingerprint(t *testing.T) {
+	t.Run("Different reference block", func(t *testing.T) {
+		txA := baseTx()
+		txB := baseTx().SetReferenceBlockID(flow.HexToID("dc7e1d3a5d2e8a3f1b4a5f9c3e0b7d6a2c1f4e8b9a0d3c6f7e2b5a8d1c4f7e0a"))
+
+		assert.NotEqual(t, txA.ID(), txB.ID())
+		assert.Equal(t, txA.Fingerprint(), txB.Fingerprint())
+	})
+
+	t.Run("Different signatures", func(t *testing.T) {
+		txA := baseTx()
+		txB := baseTx().AddEnvelopeSignature(flow.HexToAddress("01"), 4, []byte{1, 2, 3})
+
+		assert.Equal(t, txA.Fingerprint(), txB.Fingerprint())
+	})
+
+	t.Run("Different sequence number", func(t *testing.T) {
+		txA := baseTx()
+		txB := baseTx().SetProposalKey(flow.HexToAddress("01"), 4, 11)
+
+		assert.NotEqual(t, txA.Fingerprint(), txB.Fingerprint())
+	})
+}
+
+func TestTransaction_Validate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		assert.NoError(t, baseTx().Validate())
+	})
+
+	t.Run("Empty script", func(t *testing.T) {
+		tx := baseTx().SetScript(nil)
+
+		err := tx.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "script")
+	})
+
+	t.Run("Empty reference block", func(t *testing.T) {
+		tx := baseTx().SetReferenceBlockID(flow.EmptyIdentifier)
+
+		err := tx.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reference block")
+	})
+
+	t.Run("Empty payer", func(t *testing.T) {
+		tx := baseTx().SetPayer(flow.EmptyAddress)
+
+		err := tx.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "payer")
+	})
+
+	t.Run("Empty proposal key address", func(t *testing.T) {
+		tx := baseTx().SetProposalKey(flow.EmptyAddress, 0, 0)
+
+		err := tx.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "proposal key")
+	})
+
+	t.Run("Zero gas limit", func(t *testing.T) {
+		tx := baseTx().SetGasLimit(0)
+
+		err := tx.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "gas limit")
+	})
+
+	t.Run("First problem", func(t *testing.T) {
+		tx := flow.NewTransaction()
+
+		err := tx.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "script")
+	})
+}
+
+func TestTransaction_EmptyAuthorizer(t *testing.T) {
+	t.Run("Validate", func(t *testing.T) {
+		tx := baseTx().AddAuthorizer(flow.EmptyAddress)
+
+		err := tx.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "index 1")
+	})
+
+	t.Run("Checked", func(t *testing.T) {
+		tx := baseTx().SetAuthorizers(nil)
+
+		err := tx.AddAuthorizerChecked(flow.EmptyAddress)
+		assert.Error(t, err)
+		assert.Empty(t, tx.Authorizers)
+
+		err = tx.AddAuthorizerChecked(flow.HexToAddress("01"))
+		require.NoError(t, err)
+		assert.Equal(t, []flow.Address{flow.HexToAddress("01")}, tx.Authorizers)
+
+		assert.NoError(t, tx.Validate())
+	})
+}
+
+func TestTransaction_SigningDebugDump(t *testing.T) {
+	tx := baseTx().
+		AddEnvelopeSignature(flow.HexToAddress("01"), 4, []byte{0xde, 0xad, 0xbe, 0xef})
+
+	dump := tx.SigningDebugDump()
+
+	assert.Contains(t, dump, fmt.Sprintf("Transaction ID: %s", tx.ID()))
+	assert.Contains(t, dump, fmt.Sprintf("Payload message: %x", tx.PayloadMessage()))
+	assert.Contains(t, dump, fmt.Sprintf("Envelope message: %x", tx.EnvelopeMessage()))
+	assert.Contains(t, dump, "Payload signatures:\n  [0] address=0000000000000001 signerIndex=0 keyIndex=4 role=proposer,authorizer")
+	assert.Contains(t, dump, "Envelope signatures:\n  [0] address=0000000000000001 signerIndex=0 keyIndex=4 role=payer signature=deadbeef")
+}
+
+func TestTransaction_AddArrayArgument(t *testing.T) {
+	values := []cadence.Value{cadence.NewInt(1), cadence.NewInt(2), cadence.NewInt(3)}
+
+	tx := flow.NewTransaction()
+
+	err := tx.AddArrayArgument(values)
+	require.NoError(t, err)
+
+	arg, err := tx.Argument(0)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewArray(values), arg)
+}
+
+func TestTransaction_AddDictionaryArgument(t *testing.T) {
+	pairs := []cadence.KeyValuePair{
+		{Key: cadence.NewString("a"), Value: cadence.NewInt(1)},
+		{Key: cadence.NewString("b"), Value: cadence.NewInt(2)},
+	}
+
+	tx := flow.NewTransaction()
+
+	err := tx.AddDictionaryArgument(pairs)
+	require.NoError(t, err)
+
+	arg, err := tx.Argument(0)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewDictionary(pairs), arg)
+}
+
+func TestTransaction_VerifyProposalConsistency(t *testing.T) {
+	proposer := flow.HexToAddress("01")
+	payer := flow.HexToAddress("02")
+
+	sig := []byte{1, 2, 3}
+
+	t.Run("Signed with proposal key", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetProposalKey(proposer, 1, 42).
+			SetPayer(payer).
+			AddPayloadSignature(proposer, 1, sig)
+
+		assert.NoError(t, tx.VerifyProposalConsistency())
+	})
+
+	t.Run("Signed with different key index", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetProposalKey(proposer, 1, 42).
+			SetPayer(payer).
+			AddPayloadSignature(proposer, 2, sig)
+
+		assert.Error(t, tx.VerifyProposalConsistency())
+	})
+
+	t.Run("Proposer is payer", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetProposalKey(payer, 1, 42).
+			SetPayer(payer).
+			AddEnvelopeSignature(payer, 1, sig)
+
+		assert.NoError(t, tx.VerifyProposalConsistency())
+	})
+
+	t.Run("Envelope signature from non-payer proposer", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetProposalKey(proposer, 1, 42).
+			SetPayer(payer).
+			AddEnvelopeSignature(proposer, 1, sig)
+
+		assert.Error(t, tx.VerifyProposalConsistency())
+	})
+}
+
+func TestTransaction_MaxBytes(t *testing.T) {
+	t.Run("Argument exceeds limit", func(t *testing.T) {
+		tx := baseTx().SetMaxBytes(512)
+
+		err := tx.AddArgument(cadence.NewString("small"))
+		require.NoError(t, err)
+
+		err = tx.AddArgument(cadence.NewString(strings.Repeat("a", 512)))
+		assert.Error(t, err)
+
+		assert.Len(t, tx.Arguments, 1)
+		assert.LessOrEqual(t, len(tx.Encode()), 512)
+	})
+
+	t.Run("Script exceeds limit", func(t *testing.T) {
+		tx := baseTx().SetMaxBytes(512)
+
+		script := tx.Script
+
+		err := tx.SetScriptChecked([]byte(strings.Repeat("a", 512)))
+		assert.Error(t, err)
+		assert.Equal(t, script, tx.Script)
+
+		err = tx.SetScriptChecked([]byte("transaction {}"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("transaction {}"), tx.Script)
+	})
+
+	t.Run("No limit", func(t *testing.T) {
+		tx := baseTx()
+
+		err := tx.AddArgument(cadence.NewString(strings.Repeat("a", 512)))
+		assert.NoError(t, err)
+	})
+}
+
+func TestTransaction_SigningProgress(t *testing.T) {
+	addresses := test.AddressGenerator()
+
+	authorizerA := addresses.New()
+	authorizerB := addresses.New()
+	authorizerC := addresses.New()
+	payer := addresses.New()
+
+	sig := []byte{1, 2, 3}
+
+	t.Run("Three authorizers", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetProposalKey(authorizerA, 0, 42).
+			SetPayer(payer).
+			AddAuthorizer(authorizerA).
+			AddAuthorizer(authorizerB).
+			AddAuthorizer(authorizerC).
+			AddPayloadSignature(authorizerA, 0, sig)
+
+		collected, required := tx.SigningProgress()
+		assert.Equal(t, 1, collected)
+		assert.Equal(t, 4, required)
+	})
+
+	t.Run("Payer with multiple roles", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetProposalKey(payer, 0, 42).
+			SetPayer(payer).
+			AddAuthorizer(payer).
+			AddEnvelopeSignature(payer, 0, sig)
+
+		collected, required := tx.SigningProgress()
+		assert.Equal(t, 1, collected)
+		assert.Equal(t, 1, required)
+	})
+}
+
+func TestTransaction_ArgumentsJSON(t *testing.T) {
+	tx := flow.NewTransaction().
+		AddRawArgument([]byte(`{ "type": "String", "value": "foo" }`))
+
+	compact, err := tx.ArgumentsJSON(false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"type":"String","value":"foo"}`}, compact)
+
+	indented, err := tx.ArgumentsJSON(true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"{\n  \"type\": \"String\",\n  \"value\": \"foo\"\n}"}, indented)
+
+	tx.AddRawArgument([]byte(`{"type":`))
+
+	_, err = tx.ArgumentsJSON(false)
+	assert.Error(t, err)
+}
+
+func TestTransaction_DecodeFromBytes_Authorizers(t *testing.T) {
+	testDecodedAuthorizers(t, (*flow.Transaction).Encode, (*flow.Transaction).DecodeFromBytes)
+}
+
+func TestTransaction_DecodeFromPayloadBytes_Authorizers(t *testing.T) {
+	testDecodedAuthorizers(t, (*flow.Transaction).EnvelopeMessage, (*flow.Transaction).DecodeFromPayloadBytes)
+}
+
+// testDecodedAuthorizers asserts that a transaction with two distinct authorizers keeps
+// its authorizers when encoded and decoded with the given functions.
+func testDecodedAuthorizers(
+	t *testing.T,
+	encode func(*flow.Transaction) []byte,
+	decode func(*flow.Transaction, []byte) error,
+) {
+	authorizerA := flow.HexToAddress("0a")
+	authorizerB := flow.HexToAddress("0b")
+
+	tx := baseTx().
+		SetProposalKey(flow.HexToAddress("01"), 0, 0).
+		SetPayer(flow.HexToAddress("01"))
+	tx.Authorizers = []flow.Address{authorizerA, authorizerB}
+
+	decodedTx := flow.NewTransaction()
+
+	err := decode(decodedTx, encode(tx))
+	require.NoError(t, err)
+
+	assert.Equal(t, []flow.Address{authorizerA, authorizerB}, decodedTx.Authorizers)
+	assert.Equal(t, tx.PayloadMessage(), decodedTx.PayloadMessage())
+}
+
+func TestTransaction_DecodeFromEnvelopeBytes(t *testing.T) {
+	proposer := flow.HexToAddress("01")
+	payer := flow.HexToAddress("02")
+	authorizer := flow.HexToAddress("03")
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(`transaction { execute { log("Hello, World!") } }`)).
+		SetReferenceBlockID(flow.HexToID("f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b")).
+		SetGasLimit(42).
+		SetProposalKey(proposer, 3, 42).
+		SetPayer(payer).
+		AddAuthorizer(authorizer)
+
+	// sign out of signer order to check that the sorted order survives decoding
+	err := tx.SignPayload(authorizer, 1, hashSigner{})
+	require.NoError(t, err)
+
+	err = tx.SignPayload(proposer, 3, hashSigner{})
+	require.NoError(t, err)
+
+	message := tx.EnvelopeMessage()
+
+	payerTx := flow.NewTransaction()
+
+	err = payerTx.DecodeFromEnvelopeBytes(message)
+	require.NoError(t, err)
+
+	assert.Equal(t, message, payerTx.EnvelopeMessage())
+	assert.Equal(t, tx.PayloadSignatures, payerTx.PayloadSignatures)
+}
+
+func TestTransaction_SignatureOrdering(t *testing.T) {
+	signerA := flow.HexToAddress("01")
+	signerB := flow.HexToAddress("02")
+
+	tx := flow.NewTransaction().
+		SetProposalKey(signerA, 0, 0).
+		SetPayer(signerB).
+		AddAuthorizer(signerA)
+
+	tx.AddPayloadSignature(signerA, 1, []byte{1})
+	tx.AddPayloadSignature(signerA, 0, []byte{3})
+	tx.AddPayloadSignature(signerB, 0, []byte{2})
+
+	type signerKey struct {
+		SignerIndex int
+		KeyIndex    int
+	}
+
+	order := make([]signerKey, len(tx.PayloadSignatures))
+	for i, sig := range tx.PayloadSignatures {
+		order[i] = signerKey{sig.SignerIndex, sig.KeyIndex}
+	}
+
+	assert.Equal(t, []signerKey{{0, 0}, {0, 1}, {1, 0}}, order)
+}
+
+func TestTransaction_SignerList(t *testing.T) {
+	proposer := flow.HexToAddress("01")
+	payer := flow.HexToAddress("02")
+	authorizer := flow.HexToAddress("03")
+
+	tx := flow.NewTransaction().
+		SetProposalKey(proposer, 0, 0).
+		SetPayer(payer).
+		AddAuthorizer(authorizer).
+		AddAuthorizer(payer)
+
+	assert.Equal(t, []flow.Address{proposer, payer, authorizer}, tx.SignerList())
+}
+
+func TestTransaction_ReferenceBlockIDIsZero(t *testing.T) {
+	tx := flow.NewTransaction()
+	assert.True(t, tx.ReferenceBlockID.IsZero())
+
+	tx.SetReferenceBlockID(flow.HexToID("f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b"))
+	assert.False(t, tx.ReferenceBlockID.IsZero())
+}
+
+func TestTransaction_SetReferenceBlockIDFromBytes(t *testing.T) {
+	blockID := flow.HexToID("f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b")
+
+	t.Run("Correct length", func(t *testing.T) {
+		tx, err := flow.NewTransaction().SetReferenceBlockIDFromBytes(blockID.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, blockID, tx.ReferenceBlockID)
+	})
+
+	t.Run("Too short", func(t *testing.T) {
+		tx, err := flow.NewTransaction().SetReferenceBlockIDFromBytes(blockID.Bytes()[:31])
+		assert.Error(t, err)
+		assert.Equal(t, flow.EmptyID, tx.ReferenceBlockID)
+	})
+
+	t.Run("Too long", func(t *testing.T) {
+		tx, err := flow.NewTransaction().SetReferenceBlockIDFromBytes(append(blockID.Bytes(), 0))
+		assert.Error(t, err)
+		assert.Equal(t, flow.EmptyID, tx.ReferenceBlockID)
+	})
+}
+
+func TestTransaction_RemoveAuthorizer(t *testing.T) {
+	addressA := flow.HexToAddress("01")
+	addressB := flow.HexToAddress("02")
+
+	t.Run("All occurrences", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			AddAuthorizer(addressA).
+			AddAuthorizer(addressB).
+			AddAuthorizer(addressA)
+
+		tx.RemoveAuthorizer(addressA)
+
+		assert.Equal(t, []flow.Address{addressB}, tx.Authorizers)
+	})
+
+	t.Run("Not present", func(t *testing.T) {
+		tx := flow.NewTransaction().AddAuthorizer(addressB)
+
+		tx.RemoveAuthorizer(addressA)
+
+		assert.Equal(t, []flow.Address{addressB}, tx.Authorizers)
+	})
+}
+
+func TestTransaction_SetAuthorizers(t *testing.T) {
+	addressA := flow.HexToAddress("01")
+	addressB := flow.HexToAddress("02")
+
+	t.Run("Replace", func(t *testing.T) {
+		tx := flow.NewTransaction().AddAuthorizer(addressA)
+
+		tx.SetAuthorizers([]flow.Address{addressB, addressA})
+
+		assert.Equal(t, []flow.Address{addressB, addressA}, tx.Authorizers)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		tx := flow.NewTransaction().AddAuthorizer(addressA)
+
+		tx.SetAuthorizers([]flow.Address{})
+
+		assert.Empty(t, tx.Authorizers)
+	})
+}
+
+func TestTransaction_VerifyEnvelopeSignedOnlyByPayer(t *testing.T) {
+	payer := flow.HexToAddress("01")
+	other := flow.HexToAddress("02")
+
+	t.Run("Payer only", func(t *testing.T) {
+		tx := baseTx().AddEnvelopeSignature(payer, 0, []byte{1})
+
+		assert.NoError(t, tx.VerifyEnvelopeSignedOnlyByPayer())
+	})
+
+	t.Run("Extra signer", func(t *testing.T) {
+		tx := baseTx().
+			AddAuthorizer(other).
+			AddEnvelopeSignature(payer, 0, []byte{1}).
+			AddEnvelopeSignature(other, 0, []byte{2})
+
+		err := tx.VerifyEnvelopeSignedOnlyByPayer()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), other.String())
+	})
+}
+
+func TestTransaction_MessageHashes(t *testing.T) {
+	tx := baseTx()
+
+	payloadHash := tx.PayloadMessageHash()
+	envelopeHash := tx.EnvelopeMessageHash()
+
+	assert.Len(t, payloadHash, 32)
+	assert.Len(t, envelopeHash, 32)
+
+	assert.Equal(t, "ff5857cc4e5ab70633423685be695405ae32eb9f41d5ee0f2e1f1be35d90c40a", hex.EncodeToString(payloadHash))
+	assert.Equal(t, "a094463354b9c318779d109612275443afddd8deb2ed8932a0c59082d1029aed", hex.EncodeToString(envelopeHash))
+
+	assert.Equal(t, payloadHash, baseTx().PayloadMessageHash())
+	assert.Equal(t, envelopeHash, baseTx().EnvelopeMessageHash())
+}
+
+func TestTransaction_IdempotencyKey(t *testing.T) {
+	tx := baseTx()
+	key := tx.IdempotencyKey()
+
+	err := tx.SignEnvelope(flow.HexToAddress("01"), 4, hashSigner{})
+	require.NoError(t, err)
+
+	tx.SetReferenceBlockID(flow.HexToID("01"))
+
+	assert.Equal(t, key, tx.IdempotencyKey())
+	assert.True(t, strings.HasSuffix(key, "-10"))
+
+	tx.SetProposalKey(flow.HexToAddress("01"), 4, 11)
+
+	assert.NotEqual(t, key, tx.IdempotencyKey())
+}
+
+func TestTransaction_GasLimitWarning(t *testing.T) {
+	script := []byte(`transaction { execute { log("` + strings.Repeat("a", 10000) + `") } }`)
+
+	t.Run("Big script, tiny gas limit", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript(script).
+			SetGasLimit(1)
+
+		warn, suggested := tx.GasLimitWarning()
+		assert.True(t, warn)
+		assert.Greater(t, suggested, uint64(200))
+		assert.LessOrEqual(t, suggested, flow.MaxGasLimit)
+	})
+
+	t.Run("Suggested gas limit", func(t *testing.T) {
+		tx := flow.NewTransaction().SetScript(script)
+
+		_, suggested := tx.GasLimitWarning()
+		tx.SetGasLimit(suggested)
+
+		warn, _ := tx.GasLimitWarning()
+		assert.False(t, warn)
+	})
+}
+
+func TestTransaction_Copy(t *testing.T) {
+	tx := baseTx().
+		AddRawArgument([]byte(`{"type":"String","value":"foo"}`)).
+		AddEnvelopeSignature(flow.HexToAddress("01"), 4, []byte{1, 2, 3})
+
+	original := baseTx().
+		AddRawArgument([]byte(`{"type":"String","value":"foo"}`)).
+		AddEnvelopeSignature(flow.HexToAddress("01"), 4, []byte{1, 2, 3})
+
+	clone := tx.Copy()
+
+	assert.Equal(t, tx.ID(), clone.ID())
+
+	clone.Arguments[0][0] = '['
+	clone.AddRawArgument([]byte(`{"type":"Bool","value":true}`))
+	clone.Script[0] = 'T'
+	clone.Authorizers[0] = flow.HexToAddress("02")
+	clone.PayloadSignatures[0].Signature[0] ^= 0xff
+	clone.EnvelopeSignatures[0].Signature[0] ^= 0xff
+
+	assert.Equal(t, original, tx)
+	assert.NotEqual(t, tx.ID(), clone.ID())
+}
+
+func TestTransaction_DecodedArguments(t *testing.T) {
+	tx := flow.NewTransaction()
+
+	err := tx.AddArgument(cadence.NewString("foo"))
+	require.NoError(t, err)
+
+	err = tx.AddArgument(cadence.NewInt(42))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, tx.ArgumentCount())
+
+	args, err := tx.DecodedArguments()
+	require.NoError(t, err)
+	assert.Equal(t, []cadence.Value{cadence.NewString("foo"), cadence.NewInt(42)}, args)
+
+	tx.AddRawArgument([]byte(`{"type":"Int","value":`))
+
+	assert.Equal(t, 3, tx.ArgumentCount())
+
+	args, err = tx.DecodedArguments()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "index 2")
+	assert.Nil(t, args)
+}
+
+func TestTransaction_TransferSignaturesFrom(t *testing.T) {
+	src := baseTx().AddEnvelopeSignature(flow.HexToAddress("01"), 4, []byte{1, 2, 3})
+
+	t.Run("Same payload", func(t *testing.T) {
+		tx := baseTx()
+		tx.PayloadSignatures = nil
+
+		err := tx.TransferSignaturesFrom(src)
+		require.NoError(t, err)
+
+		assert.Equal(t, src.PayloadSignatures, tx.PayloadSignatures)
+		assert.Equal(t, src.EnvelopeSignatures, tx.EnvelopeSignatures)
+		assert.Equal(t, src.ID(), tx.ID())
+	})
+
+	t.Run("Different payload", func(t *testing.T) {
+		tx := baseTx().SetGasLimit(43)
+		tx.PayloadSignatures = nil
+
+		err := tx.TransferSignaturesFrom(src)
+		assert.Error(t, err)
+		assert.Empty(t, tx.PayloadSignatures)
+		assert.Empty(t, tx.EnvelopeSignatures)
+	})
+}
+
+func TestTransaction_SetArguments(t *testing.T) {
+	t.Run("Replace", func(t *testing.T) {
+		tx := flow.NewTransaction()
+
+		err := tx.AddArgument(cadence.NewString("foo"))
+		require.NoError(t, err)
+
+		err = tx.SetArguments([]cadence.Value{cadence.NewInt(1), cadence.NewInt(2)})
+		require.NoError(t, err)
+
+		args, err := tx.DecodedArguments()
+		require.NoError(t, err)
+		assert.Equal(t, []cadence.Value{cadence.NewInt(1), cadence.NewInt(2)}, args)
+	})
+
+	t.Run("Encode failure", func(t *testing.T) {
+		tx := flow.NewTransaction()
+
+		err := tx.AddArgument(cadence.NewString("foo"))
+		require.NoError(t, err)
+
+		previous := tx.Arguments
+
+		// a struct with more fields than its type declares cannot be encoded
+		invalid := cadence.NewStruct([]cadence.Value{cadence.NewInt(1)}).
+			WithType(&cadence.StructType{Identifier: "Foo"})
+
+		err = tx.SetArguments([]cadence.Value{cadence.NewInt(1), invalid, cadence.NewInt(3)})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "index 1")
+
+		assert.Equal(t, previous, tx.Arguments)
+	})
+}
+
+func TestTransaction_DefaultGasLimit(t *testing.T) {
+	tx := flow.NewTransaction()
+	assert.Equal(t, flow.DefaultGasLimit, tx.GasLimit)
+
+	tx.SetGasLimit(42)
+	assert.Equal(t, uint64(42), tx.GasLimit)
+
+	tx.SetGasLimit(0)
+	assert.Equal(t, uint64(0), tx.GasLimit)
+}
+
+func TestTransaction_String(t *testing.T) {
+	expected := `Transaction 118d6462f1c4182501d56f04a0cd23cf685283194bb316dceeb215b353120b2b
+  Script: 48 bytes, hash caa31bcffa0b9744d10ac6c546c25e7d2cefa6d5256dcadbcc459de092a49af7
+  Arguments: 0
+  Reference block ID: f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b
+  Gas limit: 42
+  Proposal key: address=0000000000000001 keyIndex=4 sequenceNumber=10
+  Payer: 0000000000000001
+  Authorizers: [0000000000000001]
+  Payload signatures: 1
+  Envelope signatures: 0
+`
+
+	assert.Equal(t, expected, baseTx().String())
+}
+
+type accountGetter map[flow.Address]*flow.Account
+
+func (g accountGetter) GetAccount(_ context.Context, address flow.Address) (*flow.Account, error) {
+	account, ok := g[address]
+	if !ok {
+		return nil, fmt.Errorf("account %s not found", address)
+	}
+
+	return account, nil
+}
+
+func TestTransaction_RequiredKeysPerAddress(t *testing.T) {
+	addresses := test.AddressGenerator()
+
+	proposer := addresses.New()
+	payer := addresses.New()
+	authorizer := addresses.New()
+
+	accounts := accountGetter{
+		payer: {
+			Address: payer,
+			Keys: []*flow.AccountKey{
+				{Index: 0, Weight: flow.AccountKeyWeightThreshold},
+				{Index: 1, Weight: flow.AccountKeyWeightThreshold},
+			},
+		},
+		authorizer: {
+			Address: authorizer,
+			Keys: []*flow.AccountKey{
+				{Index: 0, Weight: 500},
+				{Index: 1, Weight: 500},
+			},
+		},
+	}
+
+	t.Run("Two-key authorizer", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetProposalKey(proposer, 3, 42).
+			SetPayer(payer).
+			AddAuthorizer(authorizer)
+
+		keys, err := tx.RequiredKeysPerAddress(context.Background(), accounts)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[flow.Address][]int{
+			proposer:   {3},
+			payer:      {0},
+			authorizer: {0, 1},
+		}, keys)
+	})
+
+	t.Run("Proposer is also payer", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetProposalKey(payer, 1, 42).
+			SetPayer(payer)
+
+		keys, err := tx.RequiredKeysPerAddress(context.Background(), accounts)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[flow.Address][]int{payer: {1}}, keys)
+	})
+
+	t.Run("Insufficient weight", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetProposalKey(payer, 0, 42).
+			SetPayer(payer).
+			AddAuthorizer(proposer)
+
+		accounts := accountGetter{
+			payer:    accounts[payer],
+			proposer: {Address: proposer, Keys: []*flow.AccountKey{{Index: 0, Weight: 999}}},
+		}
+
+		_, err := tx.RequiredKeysPerAddress(context.Background(), accounts)
+		assert.Error(t, err)
+	})
+}
+
+func TestTransaction_AddSignatureChecked(t *testing.T) {
+	addresses := test.AddressGenerator()
+
+	proposer := addresses.New()
+	payer := addresses.New()
+	authorizer := addresses.New()
+	stranger := addresses.New()
+
+	newTx := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetProposalKey(proposer, 0, 42).
+			SetPayer(payer).
+			AddAuthorizer(authorizer)
+	}
+
+	t.Run("Known signer", func(t *testing.T) {
+		tx := newTx()
+
+		require.NoError(t, tx.AddPayloadSignatureChecked(authorizer, 0, []byte{1}))
+		require.NoError(t, tx.AddEnvelopeSignatureChecked(payer, 0, []byte{2}))
+
+		require.Len(t, tx.PayloadSignatures, 1)
+		assert.Equal(t, 2, tx.PayloadSignatures[0].SignerIndex)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, 1, tx.EnvelopeSignatures[0].SignerIndex)
+	})
+
+	t.Run("Unknown signer", func(t *testing.T) {
+		tx := newTx()
+
+		assert.Error(t, tx.AddPayloadSignatureChecked(stranger, 0, []byte{1}))
+		assert.Error(t, tx.AddEnvelopeSignatureChecked(stranger, 0, []byte{2}))
+
+		assert.Empty(t, tx.PayloadSignatures)
+		assert.Empty(t, tx.EnvelopeSignatures)
+	})
+}
+
+// roundTripTransaction builds a transaction from arbitrary field values, with one
+// payload signature per authorizer and an envelope signature from the payer.
+func roundTripTransaction(
+	script []byte,
+	argument []byte,
+	gasLimit uint64,
+	keyIndex uint16,
+	sequenceNumber uint64,
+	proposer, payer, authorizer uint64,
+	signature []byte,
+) *flow.Transaction {
+	addressFromUint := func(v uint64) flow.Address {
+		var b [flow.AddressLength]byte
+		for i := range b {
+			b[len(b)-1-i] = byte(v >> (8 * i))
+		}
+		return flow.BytesToAddress(b[:])
+	}
+
+	var referenceBlockID flow.Identifier
+	copy(referenceBlockID[:], script)
+
+	tx := flow.NewTransaction().
+		SetScript(script).
+		SetReferenceBlockID(referenceBlockID).
+		SetGasLimit(gasLimit).
+		SetProposalKey(addressFromUint(proposer), int(keyIndex), sequenceNumber).
+		SetPayer(addressFromUint(payer)).
+		AddRawArgument(argument).
+		AddAuthorizer(addressFromUint(authorizer)).
+		AddAuthorizer(addressFromUint(proposer))
+
+	tx.AddPayloadSignature(addressFromUint(authorizer), int(keyIndex), signature)
+	tx.AddPayloadSignature(addressFromUint(proposer), int(keyIndex)+1, signature)
+	tx.AddEnvelopeSignature(addressFromUint(payer), int(keyIndex), signature)
+
+	return tx
+}
+
+// assertTransactionRoundTrip asserts that decoding the encoded transaction reproduces it.
+func assertTransactionRoundTrip(t *testing.T, tx *flow.Transaction) {
+	decoded, err := flow.DecodeTransaction(tx.Encode())
+	require.NoError(t, err)
+
+	assert.Equal(t, tx.ID(), decoded.ID())
+	assert.Equal(t, tx.Encode(), decoded.Encode())
+
+	assert.Equal(t, tx.ProposalKey, decoded.ProposalKey)
+	assert.Equal(t, tx.Payer, decoded.Payer)
+	assert.Equal(t, tx.Authorizers, decoded.Authorizers)
+
+	assertSignaturesEqual := func(expected, actual []flow.TransactionSignature) {
+		require.Len(t, actual, len(expected))
+
+		for i := range expected {
+			assert.Equal(t, expected[i].Address, actual[i].Address)
+			assert.Equal(t, expected[i].SignerIndex, actual[i].SignerIndex)
+			assert.Equal(t, expected[i].KeyIndex, actual[i].KeyIndex)
+			assert.True(t, bytes.Equal(expected[i].Signature, actual[i].Signature))
+		}
+	}
+
+	assertSignaturesEqual(tx.PayloadSignatures, decoded.PayloadSignatures)
+	assertSignaturesEqual(tx.EnvelopeSignatures, decoded.EnvelopeSignatures)
+}
+
+func TestDecodeTransaction(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		assertTransactionRoundTrip(t, roundTripTransaction(
+			[]byte("transaction { execute { log(1) } }"),
+			[]byte(`{"type":"Int","value":"1"}`),
+			9999,
+			3,
+			42,
+			1, 2, 3,
+			[]byte{4, 5, 6},
+		))
+	})
+
+	t.Run("Round trip with shared roles", func(t *testing.T) {
+		assertTransactionRoundTrip(t, roundTripTransaction(nil, nil, 0, 0, 0, 7, 7, 7, nil))
+	})
+
+	t.Run("Signer index out of range", func(t *testing.T) {
+		tx := test.TransactionGenerator().New()
+		tx.PayloadSignatures[0].SignerIndex = len(tx.SignerList())
+
+		_, err := flow.DecodeTransaction(tx.Encode())
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid bytes", func(t *testing.T) {
+		_, err := flow.DecodeTransaction([]byte{0xff})
+		assert.Error(t, err)
+	})
+}
+
+func TestTransaction_AddSignature_Deduplicates(t *testing.T) {
+	addresses := test.AddressGenerator()
+
+	proposer := addresses.New()
+	payer := addresses.New()
+
+	tx := flow.NewTransaction().
+		SetProposalKey(proposer, 0, 42).
+		SetPayer(payer).
+		AddAuthorizer(proposer)
+
+	tx.AddPayloadSignature(proposer, 0, []byte{1})
+	tx.AddPayloadSignature(proposer, 1, []byte{2})
+	tx.AddPayloadSignature(proposer, 0, []byte{3})
+
+	require.Len(t, tx.PayloadSignatures, 2)
+	assert.Equal(t, 0, tx.PayloadSignatures[0].KeyIndex)
+	assert.Equal(t, []byte{3}, tx.PayloadSignatures[0].Signature)
+	assert.Equal(t, []byte{2}, tx.PayloadSignatures[1].Signature)
+
+	tx.AddEnvelopeSignature(payer, 0, []byte{4})
+	tx.AddEnvelopeSignature(payer, 0, []byte{5})
+
+	require.Len(t, tx.EnvelopeSignatures, 1)
+	assert.Equal(t, []byte{5}, tx.EnvelopeSignatures[0].Signature)
+}
+
+func TestTransaction_CheckSigningOrder(t *testing.T) {
+	addresses := test.AddressGenerator()
+
+	proposer := addresses.New()
+	payer := addresses.New()
+	authorizer := addresses.New()
+
+	newTx := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript([]byte("transaction {}")).
+			SetProposalKey(proposer, 0, 42).
+			SetPayer(payer).
+			AddAuthorizer(authorizer)
+	}
+
+	t.Run("Payload signatures before envelope", func(t *testing.T) {
+		tx := newTx()
+
+		require.NoError(t, tx.SignPayload(proposer, 0, hashSigner{}))
+		require.NoError(t, tx.SignPayload(authorizer, 0, hashSigner{}))
+		require.NoError(t, tx.SignEnvelope(payer, 0, hashSigner{}))
+
+		assert.NoError(t, tx.CheckSigningOrder())
+	})
+
+	t.Run("Late payload signature", func(t *testing.T) {
+		tx := newTx()
+
+		require.NoError(t, tx.SignPayload(proposer, 0, hashSigner{}))
+		require.NoError(t, tx.SignEnvelope(payer, 0, hashSigner{}))
+		require.NoError(t, tx.SignPayload(authorizer, 0, hashSigner{}))
+
+		assert.Error(t, tx.CheckSigningOrder())
+	})
+
+	t.Run("Envelope signatures over different messages", func(t *testing.T) {
+		tx := newTx()
+
+		require.NoError(t, tx.SignEnvelope(payer, 0, hashSigner{}))
+		tx.AddPayloadSignature(proposer, 0, []byte{1})
+		require.NoError(t, tx.SignEnvelope(payer, 1, hashSigner{}))
+
+		assert.Error(t, tx.CheckSigningOrder())
+	})
+}
+
+func TestTransactionStatus_IsFinal(t *testing.T) {
+	tests := []struct {
+		status  flow.TransactionStatus
+		isFinal bool
+		isError bool
+	}{
+		{flow.TransactionStatusUnknown, false, false},
+		{flow.TransactionStatusPending, false, false},
+		{flow.TransactionStatusFinalized, false, false},
+		{flow.TransactionStatusExecuted, false, false},
+		{flow.TransactionStatusSealed, true, false},
+		{flow.TransactionStatusExpired, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			assert.Equal(t, tt.isFinal, tt.status.IsFinal())
+			assert.Equal(t, tt.isError, tt.status.IsError())
+		})
+	}
+}
+
+func TestParseTransactionStatus(t *testing.T) {
+	statuses := []flow.TransactionStatus{
+		flow.TransactionStatusUnknown,
+		flow.TransactionStatusPending,
+		flow.TransactionStatusFinalized,
+		flow.TransactionStatusExecuted,
+		flow.TransactionStatusSealed,
+		flow.TransactionStatusExpired,
+	}
+
+	for _, status := range statuses {
+		t.Run(status.String(), func(t *testing.T) {
+			parsed, err := flow.ParseTransactionStatus(status.String())
+			require.NoError(t, err)
+			assert.Equal(t, status, parsed)
+		})
+	}
+
+	t.Run("Unknown string", func(t *testing.T) {
+		_, err := flow.ParseTransactionStatus("FOO")
+		assert.Error(t, err)
+	})
+}